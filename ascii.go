@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Terminals whose fonts lack box drawing and other symbols: the Linux and
+// BSD consoles and serial terminals
+var asciiTerms = []string{"linux", "cons25", "ansi", "vt52", "vt100", "vt102", "vt220", "vt320"}
+
+// Single-width ASCII stand-ins for the glyphs of the UI, so a view keeps its
+// layout when drawn with them
+var asciiGlyphs = map[rune]rune{
+	'╭': '+', '╮': '+', '╰': '+', '╯': '+',
+	'┌': '+', '┐': '+', '└': '+', '┘': '+',
+	'┏': '+', '┓': '+', '┗': '+', '┛': '+',
+	'╔': '+', '╗': '+', '╚': '+', '╝': '+',
+	'├': '+', '┤': '+', '┬': '+', '┴': '+', '┼': '+',
+	'┣': '+', '┫': '+', '┳': '+', '┻': '+', '╋': '+',
+	'╠': '+', '╣': '+', '╦': '+', '╩': '+', '╬': '+',
+	'─': '-', '━': '=', '═': '=',
+	'│': '|', '┃': '|', '║': '|',
+	'█': '#', '▌': '|',
+	'‹': '<', '›': '>', '«': '<', '»': '>',
+	'←': '<', '→': '>', '↑': '^', '↓': 'v',
+	'✕': 'x', '•': '*', '…': '.', '—': '-', '–': '-',
+	'‘': '\'', '’': '\'', '“': '"', '”': '"',
+}
+
+// Whether a terminal is limited to ASCII, from its TERM and the character
+// set of its locale: LC_ALL, LC_CTYPE or LANG, the first one set. Without
+// any of them (SSH clients often send none) the terminal is assumed to
+// handle UTF-8.
+func asciiTerminal(term string, environ []string) bool {
+	for _, t := range asciiTerms {
+		if term == t {
+			return true
+		}
+	}
+
+	env := map[string]string{}
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := env[key]
+		if locale == "" {
+			continue
+		}
+		_, charset, _ := strings.Cut(strings.ToLower(locale), ".")
+		charset, _, _ = strings.Cut(charset, "@")
+		return charset != "utf-8" && charset != "utf8"
+	}
+	return false
+}
+
+// Draws a view with ASCII characters only when the model is in ASCII mode:
+// glyphs get their stand-ins and accented letters lose their accents.
+// Escape sequences are ASCII already and pass through unchanged.
+func (m model) toASCII(view string) string {
+	if !m.ascii {
+		return view
+	}
+	return strings.Map(func(r rune) rune {
+		if r < 0x80 {
+			return r
+		}
+		if a, ok := asciiGlyphs[r]; ok {
+			return a
+		}
+		// é decomposes into e and a combining accent
+		if d := []rune(norm.NFD.String(string(r))); d[0] < 0x80 {
+			return d[0]
+		}
+		return r
+	}, view)
+}
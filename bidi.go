@@ -0,0 +1,335 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Languages written right to left
+var rtlLangs = map[Lang]bool{
+	AR: true,
+}
+
+func (m model) rtl() bool {
+	return rtlLangs[m.lang]
+}
+
+// Piece of a frame line. Border pieces are drawn in the border color and,
+// in right-to-left layouts, flipped along with the order of the pieces.
+type segment struct {
+	text   string
+	border bool
+}
+
+func borderSeg(s string) segment { return segment{text: s, border: true} }
+func textSeg(s string) segment   { return segment{text: s} }
+
+// Joins the pieces of a frame line, mirroring it for right-to-left languages
+func (m model) frameLine(segs ...segment) string {
+	var b strings.Builder
+	for i := range segs {
+		seg := segs[i]
+		if m.rtl() {
+			seg = segs[len(segs)-1-i]
+			if seg.border {
+				seg.text = mirrorString(seg.text)
+			}
+		}
+		if seg.border {
+			b.WriteString(m.styles.frame.Render(seg.text))
+		} else {
+			b.WriteString(seg.text)
+		}
+	}
+	return b.String()
+}
+
+// Glyphs swapped when a line is mirrored
+var mirrorGlyphs = map[rune]rune{
+	'╭': '╮', '╮': '╭', '╰': '╯', '╯': '╰',
+	'┌': '┐', '┐': '┌', '└': '┘', '┘': '└',
+	'┏': '┓', '┓': '┏', '┗': '┛', '┛': '┗',
+	'╔': '╗', '╗': '╔', '╚': '╝', '╝': '╚',
+	'├': '┤', '┤': '├', '┣': '┫', '┫': '┣', '╠': '╣', '╣': '╠',
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{',
+	'<': '>', '>': '<', '‹': '›', '›': '‹', '«': '»', '»': '«',
+}
+
+func mirrorRune(r rune) rune {
+	if m, ok := mirrorGlyphs[r]; ok {
+		return m
+	}
+	return r
+}
+
+// Reverses unstyled border text and swaps its directional glyphs
+func mirrorString(s string) string {
+	runes := []rune(s)
+	out := make([]rune, len(runes))
+	for i, r := range runes {
+		out[len(runes)-1-i] = mirrorRune(r)
+	}
+	return string(out)
+}
+
+// Pads a rendered panel line to the panel width. Right-to-left languages
+// get the line reordered for display and aligned to the right edge.
+func (m model) panelLine(line string, width int) string {
+	if !m.rtl() {
+		if w := lipgloss.Width(line); w < width {
+			line += repeatString(" ", width-w)
+		}
+		return line
+	}
+
+	line = visualOrder(line) + " "
+	if w := lipgloss.Width(line); w < width {
+		line = repeatString(" ", width-w) + line
+	}
+	return line
+}
+
+// One displayed character with the styling and hyperlink active on it
+type cell struct {
+	r    rune
+	sgr  string
+	link string
+}
+
+// Splits a styled line into cells, tracking SGR sequences and OSC 8 links
+func parseCells(s string) []cell {
+	var cells []cell
+	sgr, link := "", ""
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' && i+1 < len(s) {
+			switch s[i+1] {
+			case '[': // CSI, ends with a byte in 0x40-0x7e
+				j := i + 2
+				for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+					j++
+				}
+				if j < len(s) && s[j] == 'm' {
+					if params := s[i+2 : j]; params == "" || params == "0" {
+						sgr = ""
+					} else {
+						sgr += s[i : j+1]
+					}
+				}
+				i = j + 1
+				continue
+			case ']': // OSC, ends with BEL or ST
+				j := i + 2
+				for j < len(s) && s[j] != '\x07' && !(s[j] == '\x1b' && j+1 < len(s) && s[j+1] == '\\') {
+					j++
+				}
+				end := j + 1
+				if j < len(s) && s[j] == '\x1b' {
+					end = j + 2
+				}
+				if osc := s[i+2 : j]; strings.HasPrefix(osc, "8;") {
+					if strings.HasSuffix(osc, ";") {
+						link = ""
+					} else {
+						link = s[i:end]
+					}
+				}
+				i = end
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cells = append(cells, cell{r: r, sgr: sgr, link: link})
+		i += size
+	}
+	return cells
+}
+
+// Writes cells back out, switching styles and links only where they change
+func renderCells(cells []cell) string {
+	var b strings.Builder
+	cur := cell{}
+	for _, c := range cells {
+		if c.link != cur.link && cur.link != "" {
+			b.WriteString("\x1b]8;;\x07")
+		}
+		if c.sgr != cur.sgr {
+			b.WriteString("\x1b[0m" + c.sgr)
+		}
+		if c.link != cur.link && c.link != "" {
+			b.WriteString(c.link)
+		}
+		b.WriteRune(c.r)
+		cur = c
+	}
+	if cur.link != "" {
+		b.WriteString("\x1b]8;;\x07")
+	}
+	if cur.sgr != "" {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// Reorders a styled line from logical to display order for terminals that
+// don't implement bidi themselves (see enableExplicitBidi). Padding is
+// dropped and lines without right-to-left text keep their order.
+func visualOrder(line string) string {
+	cells := parseCells(line)
+
+	// Trim unstyled padding
+	for len(cells) > 0 && cells[0].r == ' ' && cells[0].sgr == "" {
+		cells = cells[1:]
+	}
+	for len(cells) > 0 && cells[len(cells)-1].r == ' ' && cells[len(cells)-1].sgr == "" {
+		cells = cells[:len(cells)-1]
+	}
+
+	runes := make([]rune, len(cells))
+	hasRTL := false
+	for i, c := range cells {
+		runes[i] = c.r
+		if unicode.In(c.r, unicode.Arabic, unicode.Hebrew) {
+			hasRTL = true
+		}
+	}
+	if !hasRTL {
+		return renderCells(cells)
+	}
+
+	var p bidi.Paragraph
+	if _, err := p.SetString(string(runes), bidi.DefaultDirection(bidi.RightToLeft)); err != nil {
+		return renderCells(cells)
+	}
+	o, err := p.Order()
+	if err != nil {
+		return renderCells(cells)
+	}
+	rtl := make([]bool, len(runes))
+	for i := 0; i < o.NumRuns(); i++ {
+		run := o.Run(i)
+		start, end := run.Pos()
+		for j := start; j <= end; j++ {
+			rtl[j] = run.Direction() == bidi.RightToLeft
+		}
+	}
+	resolveBrackets(runes, rtl)
+
+	// The paragraph is right to left, so runs are laid out last to first;
+	// right-to-left runs also have their characters reversed and mirrored,
+	// keeping combining marks after their base character.
+	visual := make([]cell, 0, len(cells))
+	for end := len(cells) - 1; end >= 0; {
+		start := end
+		for start > 0 && rtl[start-1] == rtl[end] {
+			start--
+		}
+		if !rtl[end] {
+			visual = append(visual, cells[start:end+1]...)
+		} else {
+			for j := end; j >= start; {
+				k := j
+				for k > start && unicode.Is(unicode.Mn, cells[k].r) {
+					k--
+				}
+				base := cells[k]
+				base.r = mirrorRune(base.r)
+				visual = append(visual, base)
+				visual = append(visual, cells[k+1:j+1]...)
+				j = k - 1
+			}
+		}
+		end = start - 1
+	}
+	return renderCells(visual)
+}
+
+// Bracket pairs resolved together by rule N0 of the bidi algorithm
+var bracketPairs = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// Applies rule N0, which x/text leaves out: a bracket pair takes the
+// direction of the text it encloses, so "SSH (Wish)" keeps its closing
+// parenthesis next to "Wish" in a right-to-left paragraph.
+func resolveBrackets(runes []rune, rtl []bool) {
+	type open struct {
+		pos   int
+		close rune
+	}
+	var stack []open
+	for i, r := range runes {
+		if c, ok := bracketPairs[r]; ok {
+			stack = append(stack, open{i, c})
+			continue
+		}
+		for j := len(stack) - 1; j >= 0; j-- {
+			if stack[j].close != r {
+				continue
+			}
+			start := stack[j].pos
+			stack = stack[:j]
+
+			// Strong direction inside the pair; the paragraph direction
+			// wins, otherwise left-to-right text needs the same context
+			// before the opening bracket
+			hasL, hasR := false, false
+			for _, inner := range runes[start+1 : i] {
+				switch strongClass(inner) {
+				case bidi.L:
+					hasL = true
+				case bidi.R:
+					hasR = true
+				}
+			}
+			switch {
+			case hasR:
+				rtl[start], rtl[i] = true, true
+			case hasL:
+				context := bidi.R
+				for k := start - 1; k >= 0; k-- {
+					if c := strongClass(runes[k]); c != bidi.ON {
+						context = c
+						break
+					}
+				}
+				rtl[start], rtl[i] = context == bidi.R, context == bidi.R
+			}
+			break
+		}
+	}
+}
+
+// Strong direction of a rune: L, R (numbers count as R, as in rule N1) or
+// ON for neutrals
+func strongClass(r rune) bidi.Class {
+	props, _ := bidi.LookupRune(r)
+	switch props.Class() {
+	case bidi.L:
+		return bidi.L
+	case bidi.R, bidi.AL, bidi.EN, bidi.AN:
+		return bidi.R
+	}
+	return bidi.ON
+}
+
+// Puts terminals with their own bidi support (ECMA-48 BDSM, e.g. VTE or
+// Konsole) in explicit mode so they display our reordered lines as is
+const enableExplicitBidi = "\x1b[8l"
+
+// Puts them back in implicit mode, their default, once the TUI exits, so the
+// shell's own right-to-left text is laid out again
+const resetExplicitBidi = "\x1b[8h"
+
+// Resets the bidi mode of a session's terminal after its program exits
+func bidiMiddleware(next ssh.Handler) ssh.Handler {
+	return func(s ssh.Session) {
+		next(s)
+		if _, _, ok := s.Pty(); ok {
+			io.WriteString(s, resetExplicitBidi)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"left to right only", "Hello (world)", "Hello (world)"},
+		{"right to left", "مرحبا", "ابحرم"},
+		{"padding dropped", "  مرحبا  ", "ابحرم"},
+		{"mirrored brackets", "(مرحبا)", "(ابحرم)"},
+		{"left-to-right run", "خادم SSH (Wish)", "SSH (Wish) مداخ"},
+		{"left-to-right runs apart", "أداة Go و Docker", "Docker و Go ةادأ"},
+		{"number and bracketed run", "الإصدار 1.2 (Go)", "(Go) 1.2 رادصإلا"},
+		{"brackets before right to left", "[ Go ] عربي", "يبرع [ Go ]"},
+	}
+	for _, tt := range tests {
+		if got := visualOrder(tt.in); got != tt.want {
+			t.Errorf("%s: visualOrder(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestResolveBrackets(t *testing.T) {
+	const T, F = true, false
+	tests := []struct {
+		name string
+		in   string
+		rtl  []bool
+		want []bool
+	}{
+		{"right to left inside", "(ع)", []bool{F, T, F}, []bool{T, T, T}},
+		{"left to right inside, after right to left", "ع (Go)", []bool{T, T, F, F, F, F}, []bool{T, T, T, F, F, T}},
+		{"left to right inside, after left to right", "Go (Go)", []bool{F, F, F, F, F, F, F}, []bool{F, F, F, F, F, F, F}},
+		{"left to right inside, at the start", "(Go) ع", []bool{F, F, F, F, T, T}, []bool{T, F, F, T, T, T}},
+		{"neutral inside", "ع (..)", []bool{T, T, F, F, F, F}, []bool{T, T, F, F, F, F}},
+		{"unmatched", "(ع]", []bool{F, T, F}, []bool{F, T, F}},
+		{"nested", "(Go [ع])", []bool{F, F, F, F, F, T, F, F}, []bool{T, F, F, F, T, T, T, T}},
+	}
+	for _, tt := range tests {
+		got := slices.Clone(tt.rtl)
+		resolveBrackets([]rune(tt.in), got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: resolveBrackets(%q, %v) = %v, want %v", tt.name, tt.in, tt.rtl, got, tt.want)
+		}
+	}
+}
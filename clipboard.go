@@ -0,0 +1,68 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// How long a toast replaces the footer
+const toastDuration = 2 * time.Second
+
+// Ends the toast with the same id
+type toastExpiredMsg struct{ id int }
+
+// The contact block as text in the model's language: name, title, email,
+// phone, location and profile URLs
+func (m model) contactText() string {
+	lines := []string{owner.Name, m.t("subtitle"), owner.Email, owner.Phone, m.t("home_location")}
+	for _, l := range contactLinks()[1:] {
+		lines = append(lines, l.url)
+	}
+	lines = slices.DeleteFunc(lines, func(line string) bool { return line == "" })
+	return strings.Join(lines, "\n")
+}
+
+// Copies the focused link, or the contact block when no link has the focus,
+// to the visitor's clipboard. OSC 52 goes through SSH to the visitor's own
+// terminal, which may or may not accept it, so the toast says the text was
+// sent rather than copied.
+//
+// The sequence is written on its own to the program's output, in one write
+// as the renderer writes each frame, so that it can't land in the middle of
+// one.
+func (m *model) yank() tea.Cmd {
+	f, isLink := m.focusedLink()
+	text := m.contactText()
+	if isLink {
+		text = strings.TrimPrefix(f.url, "mailto:")
+	}
+
+	out := m.renderer.Output()
+	write := func() tea.Msg {
+		out.WriteString(ansi.SetSystemClipboard(text))
+		return nil
+	}
+	return tea.Batch(write, m.showToast(m.copyMessage(text, isLink)))
+}
+
+// Toast after a copy of a link or the contact block
+func (m model) copyMessage(text string, isLink bool) string {
+	if isLink {
+		return m.tf("copied_link", args{"text": text})
+	}
+	return m.t("copied_contact")
+}
+
+// Shows a message in place of the footer for a while
+func (m *model) showToast(text string) tea.Cmd {
+	m.toastID++
+	m.toast = text
+	id := m.toastID
+	return after(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id}
+	})
+}
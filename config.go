@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"termfolio/data"
+)
+
+// Settings read from config.toml in the termfolio config directory
+type config struct {
+	Font       string `toml:"font"`       // banner font: an embedded one or a .flf file
+	Hyperlinks *bool  `toml:"hyperlinks"` // OSC 8 links or footnotes; unset detects
+}
+
+// Directory of termfolio's settings and user themes in the user's config
+// directory, e.g. ~/.config/termfolio
+func configDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "termfolio")
+}
+
+// Reads a config file and applies it. A missing file leaves the defaults.
+func loadConfig(file string) error {
+	var c config
+	if _, err := toml.DecodeFile(file, &c); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("config: %s: %v", file, err)
+	}
+
+	if c.Font != "" {
+		f, err := findFont(c.Font)
+		if err != nil {
+			return fmt.Errorf("config: %s: font: %v", file, err)
+		}
+		bannerFont = f
+	}
+	hyperlinksSetting = c.Hyperlinks
+	return nil
+}
+
+// Reads a resume file, such as import linkedin writes, in place of the
+// built-in resume. A missing file leaves the built-in one.
+func loadResume(file string) error {
+	if err := data.Load(file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("resume: %v", err)
+	}
+	owner = data.GetResume().Contact
+	return nil
+}
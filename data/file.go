@@ -0,0 +1,55 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Resume read from a resume file, which GetResume returns instead of the
+// built-in one
+var loaded *Resume
+
+// Reads a resume file, rejecting keys the resume has no field for
+func Decode(r io.Reader) (Resume, error) {
+	var resume Resume
+	md, err := toml.NewDecoder(r).Decode(&resume)
+	if err != nil {
+		return resume, err
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return resume, fmt.Errorf("unknown key %q", undecoded[0].String())
+	}
+	return resume, nil
+}
+
+// Reads the resume file at name
+func ReadFile(name string) (Resume, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Resume{}, err
+	}
+	defer f.Close()
+	r, err := Decode(f)
+	if err != nil {
+		return r, fmt.Errorf("%s: %w", name, err)
+	}
+	return r, nil
+}
+
+// Reads the resume file at name for GetResume to return
+func Load(name string) error {
+	r, err := ReadFile(name)
+	if err != nil {
+		return err
+	}
+	loaded = &r
+	return nil
+}
+
+// Writes the resume as a resume file
+func (r Resume) Encode(w io.Writer) error {
+	return toml.NewEncoder(w).Encode(r)
+}
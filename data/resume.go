@@ -2,54 +2,15 @@ package data
 
 // Resume data for Mohamed GACHA's termfolio
 
-type Contact struct {
-	Name     string
-	Title    string
-	Email    string
-	Phone    string
-	Location string
-	LinkedIn string
-	GitHub   string
-}
-
-type Experience struct {
-	Title    string
-	Company  string
-	Period   string
-	Location string
-	Desc     string
-	Tags     []string
-}
-
-type Project struct {
-	Name string
-	Desc string
-	Date string
-	Tags []string
-	URL  string
-}
-
-type Education struct {
-	Degree string
-	School string
-	Period string
-	Desc   string
-}
-
-type Resume struct {
-	Contact    Contact
-	Experiences []Experience
-	Projects   []Project
-	Education  []Education
-	Skills     []string
-	Languages  map[string]string
-	Interests  []string
-}
-
 func GetResume() Resume {
+	if loaded != nil {
+		return *loaded
+	}
 	return Resume{
+		Lang: "fr",
 		Contact: Contact{
 			Name:     "Mohamed GACHA",
+			Gender:   "male",
 			Title:    "Ingénieur en informatique et réseaux",
 			Email:    "simogacha@gmail.com",
 			Phone:    "+33 6 05 76 45 74",
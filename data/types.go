@@ -0,0 +1,50 @@
+package data
+
+// Types of the resume data, which GetResume returns and resume files
+// write in TOML
+
+type Contact struct {
+	Name     string `toml:"name"`
+	Gender   string `toml:"gender,omitempty"` // "male", "female" or "other"; picks gendered wording in translations
+	Title    string `toml:"title"`
+	Email    string `toml:"email"`
+	Phone    string `toml:"phone,omitempty"`
+	Location string `toml:"location"`
+	LinkedIn string `toml:"linkedin,omitempty"`
+	GitHub   string `toml:"github,omitempty"`
+}
+
+type Experience struct {
+	Title    string   `toml:"title"`
+	Company  string   `toml:"company"`
+	Period   string   `toml:"period"`
+	Location string   `toml:"location,omitempty"`
+	Desc     string   `toml:"desc"`
+	Tags     []string `toml:"tags,omitempty"`
+}
+
+type Project struct {
+	Name string   `toml:"name"`
+	Desc string   `toml:"desc"`
+	Date string   `toml:"date"`
+	Tags []string `toml:"tags,omitempty"`
+	URL  string   `toml:"url,omitempty"`
+}
+
+type Education struct {
+	Degree string `toml:"degree"`
+	School string `toml:"school"`
+	Period string `toml:"period"`
+	Desc   string `toml:"desc"`
+}
+
+type Resume struct {
+	Lang        string            `toml:"lang"` // language the resume is written in, e.g. "fr"
+	Contact     Contact           `toml:"contact"`
+	Experiences []Experience      `toml:"experiences"`
+	Projects    []Project         `toml:"projects"`
+	Education   []Education       `toml:"education"`
+	Skills      []string          `toml:"skills"`
+	Languages   map[string]string `toml:"languages"`
+	Interests   []string          `toml:"interests"`
+}
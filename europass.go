@@ -0,0 +1,422 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"golang.org/x/text/unicode/norm"
+
+	"termfolio/data"
+)
+
+// Europass CV, version 3 of the schema. The same structures give the XML
+// document and its JSON form, where lists drop their *List wrapper.
+type europassCV struct {
+	XMLName      xml.Name `xml:"http://europass.cedefop.europa.eu/Europass SkillsPassport" json:"-"`
+	Locale       string   `xml:"locale,attr"`
+	DocumentInfo struct {
+		DocumentType string
+		XSDVersion   string
+		Generator    string
+	}
+	LearnerInfo europassLearner
+}
+
+type europassLearner struct {
+	Identification struct {
+		PersonName struct {
+			FirstName string
+			Surname   string
+		}
+		ContactInfo europassContactInfo
+	}
+	Headline struct {
+		Type        europassCode
+		Description europassCode
+	}
+	WorkExperience europassList[europassWork]      `xml:"WorkExperienceList"`
+	Education      europassList[europassEducation] `xml:"EducationList"`
+	Skills         struct {
+		Linguistic struct {
+			MotherTongue    europassList[europassLanguage] `xml:"MotherTongueList,omitempty" json:",omitempty"`
+			ForeignLanguage europassList[europassLanguage] `xml:"ForeignLanguageList,omitempty" json:",omitempty"`
+		}
+		Computer struct{ Description string }
+	}
+}
+
+type europassContactInfo struct {
+	Address *europassAddress `xml:",omitempty" json:",omitempty"`
+	Email   *struct {
+		Contact string
+	} `xml:",omitempty" json:",omitempty"`
+	Telephone europassList[europassContact] `xml:"TelephoneList,omitempty" json:",omitempty"`
+	Website   europassList[europassContact] `xml:"WebsiteList,omitempty" json:",omitempty"`
+}
+
+// A list, wrapped in XML in an element named after its items:
+// <TelephoneList><Telephone>…</Telephone></TelephoneList>
+type europassList[T any] []T
+
+func (l europassList[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	item := xml.StartElement{Name: xml.Name{Local: strings.TrimSuffix(start.Name.Local, "List")}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, v := range l {
+		if err := e.EncodeElement(v, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+type europassAddress struct {
+	Contact struct {
+		Municipality string        `xml:",omitempty" json:",omitempty"`
+		Country      *europassCode `xml:",omitempty" json:",omitempty"`
+	}
+}
+
+type europassContact struct {
+	Contact string
+	Use     europassCode
+}
+
+// A value of a Europass list, such as a language or a telephone use
+type europassCode struct {
+	Code  string `xml:",omitempty" json:",omitempty"`
+	Label string `xml:",omitempty" json:",omitempty"`
+}
+
+type europassWork struct {
+	Period     europassPeriod
+	Position   europassCode
+	Activities string
+	Employer   europassOrganisation
+}
+
+type europassEducation struct {
+	Period       europassPeriod
+	Title        string
+	Activities   string `xml:",omitempty" json:",omitempty"`
+	Organisation europassOrganisation
+}
+
+type europassOrganisation struct {
+	Name        string
+	ContactInfo *europassContactInfo `xml:",omitempty" json:",omitempty"`
+}
+
+type europassPeriod struct {
+	From    *europassDate `xml:",omitempty" json:",omitempty"`
+	To      *europassDate `xml:",omitempty" json:",omitempty"`
+	Current bool          `xml:",omitempty" json:",omitempty"`
+}
+
+// A year and month, as attributes in XML (year="2025" month="--10")
+type europassDate struct {
+	Year  int
+	Month int `json:",omitempty"`
+}
+
+func (d europassDate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "year"}, Value: strconv.Itoa(d.Year)})
+	if d.Month > 0 {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "month"}, Value: fmt.Sprintf("--%02d", d.Month)})
+	}
+	return e.EncodeElement(struct{}{}, start)
+}
+
+type europassLanguage struct {
+	Description      europassCode
+	ProficiencyLevel *europassLevels `xml:",omitempty" json:",omitempty"`
+}
+
+// CEFR levels of the five skills of the self-assessment grid
+type europassLevels struct {
+	Listening, Reading, SpokenInteraction, SpokenProduction, Writing string
+}
+
+// Lowercases s and strips its accents, to match free text
+func foldKey(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(strings.TrimSpace(s))) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Free-text language levels, in the languages resumes are written in, and
+// their CEFR level; "native" marks a mother tongue
+var cefrLevels = map[string]string{
+	"natale": "native", "native": "native", "natif": "native", "maternelle": "native",
+	"langue maternelle": "native", "nativo": "native", "lengua materna": "native", "mother tongue": "native",
+	"bilingue": "C2", "bilingual": "C2", "maitrise": "C2", "proficient": "C2",
+	"courant": "C1", "fluent": "C1", "fluide": "C1", "fluido": "C1", "avance": "C1", "advanced": "C1", "avanzado": "C1",
+	"professionnel": "B2", "professional": "B2", "operationnel": "B2", "upper intermediate": "B2",
+	"intermediaire": "B1", "intermediate": "B1", "intermedio": "B1",
+	"scolaire": "A2", "elementaire": "A2", "elementary": "A2", "basico": "A2", "notions": "A2", "basic": "A2",
+	"en cours d'apprentissage": "A2", "en apprentissage": "A2", "learning": "A2", "aprendiendo": "A2",
+	"debutant": "A1", "beginner": "A1", "principiante": "A1",
+	// LinkedIn's proficiencies
+	"native or bilingual proficiency": "native", "full professional proficiency": "C1",
+	"professional working proficiency": "B2", "limited working proficiency": "B1", "elementary proficiency": "A2",
+}
+
+// CEFR level of a free-text level such as "Courant" or "Natale", or a CEFR
+// code itself ("B2"); "native" for a mother tongue and "" when unknown
+func cefrLevel(level string) string {
+	key := foldKey(level)
+	if len(key) == 2 && strings.Contains("abc", key[:1]) && strings.Contains("12", key[1:]) {
+		return strings.ToUpper(key)
+	}
+	return cefrLevels[key]
+}
+
+// Names of languages as resumes write them, in English, French and Spanish
+var languageNames = map[string]string{
+	"anglais": "en", "english": "en", "ingles": "en",
+	"francais": "fr", "french": "fr", "frances": "fr",
+	"espagnol": "es", "spanish": "es", "espanol": "es",
+	"arabe": "ar", "arabic": "ar",
+	"allemand": "de", "german": "de", "aleman": "de",
+	"italien": "it", "italian": "it", "italiano": "it",
+	"portugais": "pt", "portuguese": "pt", "portugues": "pt",
+	"chinois": "zh", "chinese": "zh", "chino": "zh",
+	"japonais": "ja", "japanese": "ja", "japones": "ja",
+}
+
+// Month names and abbreviations of the resume's periods
+var monthNames = map[string]int{
+	"jan": 1, "janv": 1, "janvier": 1, "january": 1, "enero": 1, "ene": 1,
+	"feb": 2, "fev": 2, "fevr": 2, "fevrier": 2, "february": 2, "febrero": 2,
+	"mar": 3, "mars": 3, "march": 3, "marzo": 3,
+	"apr": 4, "avr": 4, "avril": 4, "april": 4, "abr": 4, "abril": 4,
+	"may": 5, "mai": 5, "mayo": 5,
+	"jun": 6, "juin": 6, "june": 6, "junio": 6,
+	"jul": 7, "juil": 7, "juillet": 7, "july": 7, "julio": 7,
+	"aug": 8, "aou": 8, "aout": 8, "august": 8, "ago": 8, "agosto": 8,
+	"sep": 9, "sept": 9, "septembre": 9, "september": 9, "septiembre": 9,
+	"oct": 10, "octobre": 10, "october": 10, "octubre": 10,
+	"nov": 11, "novembre": 11, "november": 11, "noviembre": 11,
+	"dec": 12, "decembre": 12, "december": 12, "dic": 12, "diciembre": 12,
+
+	// Arabic, as foldKey leaves them: without hamza or madda. The Middle
+	// East's names, then the Maghreb's, then the Levant's.
+	"يناير": 1, "فبراير": 2, "مارس": 3, "ابريل": 4, "مايو": 5, "يونيو": 6,
+	"يوليو": 7, "اغسطس": 8, "سبتمبر": 9, "اكتوبر": 10, "نوفمبر": 11, "ديسمبر": 12,
+	"ماي": 5, "يوليوز": 7, "غشت": 8, "شتنبر": 9, "نونبر": 11, "دجنبر": 12,
+	"جانفي": 1, "فيفري": 2, "افريل": 4, "جوان": 6, "جويلية": 7, "اوت": 8,
+	"كانون الثاني": 1, "شباط": 2, "اذار": 3, "نيسان": 4, "ايار": 5, "حزيران": 6,
+	"تموز": 7, "اب": 8, "ايلول": 9, "تشرين الاول": 10, "تشرين الثاني": 11, "كانون الاول": 12,
+}
+
+// Parses a date of a period: "2021", "Oct 2025", "Juin 2024" or
+// "يونيو 2024". The year may be left out after a month, "Jun", for the
+// period to take it from its end.
+func parseEuropassDate(s string) (*europassDate, bool) {
+	fields := strings.Fields(foldKey(s))
+	if len(fields) == 0 {
+		return nil, false
+	}
+	d := &europassDate{}
+	if year, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+		d.Year = year
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) > 0 {
+		if d.Month = monthNames[strings.TrimSuffix(strings.Join(fields, " "), ".")]; d.Month == 0 {
+			return nil, false
+		}
+	}
+	return d, true
+}
+
+// Parses a period such as "Sept 2023 - Sept 2026", "Jun - Aug 2024",
+// "Sept 2025 - En cours" or "2021". A start without a year takes the end's,
+// or the year before when its month comes later; an end that isn't a date
+// means the period goes on.
+func parseEuropassPeriod(s string) (europassPeriod, bool) {
+	from, to, ranged := strings.Cut(s, " - ")
+	var p europassPeriod
+	var ok bool
+	if p.From, ok = parseEuropassDate(from); !ok {
+		return p, false
+	}
+	if ranged {
+		if p.To, ok = parseEuropassDate(to); !ok || p.To.Year == 0 {
+			p.To, p.Current = nil, true
+		}
+	}
+	if p.From.Year == 0 {
+		if p.To == nil {
+			return p, false
+		}
+		p.From.Year = p.To.Year
+		if p.To.Month > 0 && p.From.Month > p.To.Month {
+			p.From.Year--
+		}
+	}
+	return p, true
+}
+
+// Splits "Carnot prépas | Meknes, Maroc" into the name and the place
+func europassOrg(s string) europassOrganisation {
+	name, place, ok := strings.Cut(s, " | ")
+	org := europassOrganisation{Name: strings.TrimSpace(name)}
+	if ok {
+		org.ContactInfo = &europassContactInfo{Address: &europassAddress{}}
+		org.ContactInfo.Address.Contact.Municipality = strings.TrimSpace(place)
+	}
+	return org
+}
+
+// The resume data as a Europass CV, in the language it is written in.
+// Problems, such as language levels without a CEFR equivalent, are
+// returned along with it: the CV leaves those parts out.
+func europass(r data.Resume) (europassCV, []string) {
+	var problems []string
+	var cv europassCV
+	cv.Locale = r.Lang
+	cv.DocumentInfo.DocumentType = "ECV"
+	cv.DocumentInfo.XSDVersion = "V3.3"
+	cv.DocumentInfo.Generator = "termfolio"
+
+	l := &cv.LearnerInfo
+	c := r.Contact
+	id := &l.Identification
+	id.PersonName.FirstName, id.PersonName.Surname = c.Name, ""
+	if i := strings.LastIndex(c.Name, " "); i >= 0 {
+		id.PersonName.FirstName, id.PersonName.Surname = c.Name[:i], c.Name[i+1:]
+	}
+	if c.Location != "" {
+		city, _, country := splitLocation(c.Location)
+		id.ContactInfo.Address = &europassAddress{}
+		id.ContactInfo.Address.Contact.Municipality = city
+		id.ContactInfo.Address.Contact.Country = &europassCode{Label: country}
+	}
+	if c.Email != "" {
+		id.ContactInfo.Email = &struct{ Contact string }{c.Email}
+	}
+	if c.Phone != "" {
+		id.ContactInfo.Telephone = europassList[europassContact]{{c.Phone, europassCode{Code: "mobile"}}}
+	}
+	if c.LinkedIn != "" {
+		id.ContactInfo.Website = append(id.ContactInfo.Website, europassContact{"https://linkedin.com/in/" + c.LinkedIn, europassCode{Code: "personal"}})
+	}
+	if c.GitHub != "" {
+		id.ContactInfo.Website = append(id.ContactInfo.Website, europassContact{"https://github.com/" + c.GitHub, europassCode{Code: "personal"}})
+	}
+	l.Headline.Type = europassCode{Code: "position"}
+	l.Headline.Description = europassCode{Label: c.Title}
+
+	for _, e := range r.Experiences {
+		period, ok := parseEuropassPeriod(e.Period)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("experience %q: unknown period %q", e.Company, e.Period))
+		}
+		work := europassWork{
+			Period:     period,
+			Position:   europassCode{Label: e.Title},
+			Activities: e.Desc,
+			Employer:   europassOrganisation{Name: e.Company},
+		}
+		if len(e.Tags) > 0 {
+			work.Activities += "\n" + strings.Join(e.Tags, ", ")
+		}
+		if e.Location != "" {
+			work.Employer.ContactInfo = &europassContactInfo{Address: &europassAddress{}}
+			work.Employer.ContactInfo.Address.Contact.Municipality = e.Location
+		}
+		l.WorkExperience = append(l.WorkExperience, work)
+	}
+
+	for _, e := range r.Education {
+		period, ok := parseEuropassPeriod(e.Period)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("education %q: unknown period %q", e.Degree, e.Period))
+		}
+		l.Education = append(l.Education, europassEducation{
+			Period:       period,
+			Title:        e.Degree,
+			Activities:   e.Desc,
+			Organisation: europassOrg(e.School),
+		})
+	}
+
+	// Languages are named in the resume's language
+	namer := display.Languages(language.Make(r.Lang))
+	for _, name := range slices.Sorted(maps.Keys(r.Languages)) {
+		lang := europassLanguage{Description: europassCode{Label: name}}
+		if code, ok := languageNames[foldKey(name)]; ok {
+			lang.Description.Code = code
+			if namer != nil {
+				if n := []rune(namer.Name(language.Make(code))); len(n) > 0 {
+					lang.Description.Label = string(unicode.ToUpper(n[0])) + string(n[1:])
+				}
+			}
+		} else {
+			problems = append(problems, fmt.Sprintf("language %q: no ISO 639 code", name))
+		}
+
+		switch level := cefrLevel(r.Languages[name]); level {
+		case "native":
+			l.Skills.Linguistic.MotherTongue = append(l.Skills.Linguistic.MotherTongue, lang)
+		case "":
+			problems = append(problems, fmt.Sprintf("language %q: no CEFR level for %q", name, r.Languages[name]))
+			l.Skills.Linguistic.ForeignLanguage = append(l.Skills.Linguistic.ForeignLanguage, lang)
+		default:
+			lang.ProficiencyLevel = &europassLevels{level, level, level, level, level}
+			l.Skills.Linguistic.ForeignLanguage = append(l.Skills.Linguistic.ForeignLanguage, lang)
+		}
+	}
+	l.Skills.Computer.Description = strings.Join(r.Skills, ", ")
+
+	return cv, problems
+}
+
+// Europass CV of the resume data, logging what couldn't be mapped
+func europassFromResume() europassCV {
+	cv, problems := europass(data.GetResume())
+	for _, problem := range problems {
+		log.Printf("europass: %s", problem)
+	}
+	return cv
+}
+
+// Writes the Europass CV as XML (export --format europass), the form
+// Europass tools import. It is in the resume data's language whatever
+// --lang says, as the descriptions are.
+func writeEuropassXML(w io.Writer, o exportOptions) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(europassFromResume()); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Writes the Europass CV as JSON (export --format europass-json)
+func writeEuropassJSON(w io.Writer, o exportOptions) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	e.SetEscapeHTML(false)
+	return e.Encode(struct{ SkillsPassport europassCV }{europassFromResume()})
+}
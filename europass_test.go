@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEuropassPeriod(t *testing.T) {
+	date := func(year, month int) *europassDate {
+		return &europassDate{Year: year, Month: month}
+	}
+	tests := []struct {
+		in     string
+		want   europassPeriod
+		wantOK bool
+	}{
+		{"2021", europassPeriod{From: date(2021, 0)}, true},
+		{"2018 - 2021", europassPeriod{From: date(2018, 0), To: date(2021, 0)}, true},
+		{"Oct 2025 - Sept 2026", europassPeriod{From: date(2025, 10), To: date(2026, 9)}, true},
+		{"Sept 2025 - En cours", europassPeriod{From: date(2025, 9), Current: true}, true},
+		{"Feb. 2023 - Present", europassPeriod{From: date(2023, 2), Current: true}, true},
+
+		// A start without a year takes the end's
+		{"Jun - Aug 2024", europassPeriod{From: date(2024, 6), To: date(2024, 8)}, true},
+		{"Juin - Août 2024", europassPeriod{From: date(2024, 6), To: date(2024, 8)}, true},
+		{"Junio - Agosto 2024", europassPeriod{From: date(2024, 6), To: date(2024, 8)}, true},
+		{"Nov - Feb 2024", europassPeriod{From: date(2023, 11), To: date(2024, 2)}, true},
+
+		// Arabic, from the Middle East, the Maghreb and the Levant
+		{"يونيو - غشت 2024", europassPeriod{From: date(2024, 6), To: date(2024, 8)}, true},
+		{"أكتوبر 2025 - سبتمبر 2026", europassPeriod{From: date(2025, 10), To: date(2026, 9)}, true},
+		{"جوان - أوت 2024", europassPeriod{From: date(2024, 6), To: date(2024, 8)}, true},
+		{"تشرين الأول 2025 - آب 2026", europassPeriod{From: date(2025, 10), To: date(2026, 8)}, true},
+
+		{"Jun", europassPeriod{}, false},
+		{"Jun - En cours", europassPeriod{}, false},
+		{"Summer 2024", europassPeriod{}, false},
+		{"", europassPeriod{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseEuropassPeriod(tt.in)
+		if ok != tt.wantOK {
+			t.Errorf("parseEuropassPeriod(%q) ok = %v, want %v", tt.in, ok, tt.wantOK)
+			continue
+		}
+		if ok && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseEuropassPeriod(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCEFRLevel(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Courant", "C1"},
+		{"Natale", "native"},
+		{"En cours d'apprentissage", "A2"},
+		{"Langue maternelle", "native"},
+		{"Intermédiaire", "B1"},
+		{"Básico", "A2"},
+		{"Full professional proficiency", "C1"},
+		{"b2", "B2"},
+		{"C1", "C1"},
+		{"D1", ""},
+		{"Excellent", ""},
+	}
+	for _, tt := range tests {
+		if got := cefrLevel(tt.in); got != tt.want {
+			t.Errorf("cefrLevel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// What an export is made with
+type exportOptions struct {
+	lang  Lang
+	theme int // index in themes; the default theme when unset
+
+	// Tab (index in tabNames) and terminal size of screenshots
+	tab           int
+	width, height int
+}
+
+// A model to translate and style the export with
+func (o exportOptions) model() model {
+	m := initialModel(o.lang)
+	m.setTheme(o.theme)
+	return m
+}
+
+// The frame of the tab a screenshot shows, in color
+func (o exportOptions) frame() string {
+	return frameOptions{lang: o.lang, theme: o.theme, tab: o.tab, width: o.width, height: o.height, color: true}.frame()
+}
+
+// Export formats: each writes the resume, or part of it, to w
+var exporters = map[string]func(w io.Writer, o exportOptions) error{
+	"europass":      writeEuropassXML,
+	"europass-json": writeEuropassJSON,
+	"html":          writeHTML,
+	"md":            writeMarkdown,
+	"pdf":           writePDF,
+	"png":           writePNG,
+	"svg":           writeSVG,
+	"tex":           writeLaTeX,
+	"txt":           writePlainText,
+	"vcf":           writeVCard,
+}
+
+func exportFormats() []string {
+	return slices.Sorted(maps.Keys(exporters))
+}
+
+// termfolio export --format <format> [--lang <lang>] [--theme <theme>]
+// [--tab <tab>] [--size WIDTHxHEIGHT] [--output <file>]
+func exportCommand(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "", "Export format ("+strings.Join(exportFormats(), ", ")+")")
+	langFlag := fs.String("lang", "", "Language of the export; defaults to the locale")
+	themeFlag := fs.String("theme", "", "Color theme of the export ("+strings.Join(themeNames(), ", ")+", or a user theme)")
+	themesDir := fs.String("themes", themeDir(), "Directory of user themes (*.toml)")
+	resumeFile := fs.String("resume", filepath.Join(configDir(), "resume.toml"), "Resume file; defaults to the built-in resume when missing")
+	tabFlag := fs.String("tab", tabNames[0], "Tab of png and svg screenshots ("+strings.Join(tabNames, ", ")+")")
+	sizeFlag := fs.String("size", "80x24", "Terminal size of png and svg screenshots, WIDTHxHEIGHT")
+	output := fs.String("output", "", "File to write; defaults to standard output")
+	fs.StringVar(output, "o", "", "Shorthand for --output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	export, ok := exporters[*format]
+	if !ok {
+		return fmt.Errorf("unknown format %q (available: %s)", *format, strings.Join(exportFormats(), ", "))
+	}
+	lang, err := startLang(*langFlag)
+	if err != nil {
+		return err
+	}
+	if err := loadResume(*resumeFile); err != nil {
+		return err
+	}
+	for _, err := range loadThemes(*themesDir) {
+		log.Print(err)
+	}
+	theme, err := startTheme(*themeFlag)
+	if err != nil {
+		return err
+	}
+	tab, err := tabIndex(*tabFlag)
+	if err != nil {
+		return err
+	}
+	width, height, err := parseSize(*sizeFlag)
+	if err != nil {
+		return err
+	}
+	o := exportOptions{lang: lang, theme: max(theme, 0), tab: tab, width: width, height: height}
+
+	if *output == "" {
+		return export(os.Stdout, o)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := export(f, o); err != nil {
+		f.Close()
+		os.Remove(*output)
+		return err
+	}
+	return f.Close()
+}
+
+// Commands an SSH client can run instead of the TUI, e.g.
+// ssh -p 23234 host vcard > me.vcf
+var sshCommands = map[string]string{
+	"vcard": "vcf",
+}
+
+// Serves the exports to SSH commands, in the language forced with --lang or
+// the client's locale. Sessions without a command go on to the TUI, or get
+// the plain-text resume when they have no terminal (ssh -T host > cv.txt),
+// as wide as the COLUMNS the client sends or 76 columns.
+func exportMiddleware(forced Lang) func(ssh.Handler) ssh.Handler {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			command := s.Command()
+			_, _, isPty := s.Pty()
+			if len(command) == 0 && isPty {
+				next(s)
+				return
+			}
+			lang := forced
+			if lang == "" {
+				lang = langFromEnv(s.Environ())
+			}
+			if len(command) == 0 {
+				io.WriteString(s, exportOptions{lang: lang}.model().plainText(environWidth(s.Environ())))
+				s.Exit(0)
+				return
+			}
+
+			format, ok := sshCommands[command[0]]
+			if !ok || len(command) > 1 {
+				fmt.Fprintf(s.Stderr(), "Unknown command %q (available: %s)\n", strings.Join(command, " "), strings.Join(slices.Sorted(maps.Keys(sshCommands)), ", "))
+				s.Exit(1)
+				return
+			}
+			if err := exporters[format](s, exportOptions{lang: lang}); err != nil {
+				fmt.Fprintf(s.Stderr(), "Error: %v\n", err)
+				s.Exit(1)
+				return
+			}
+			s.Exit(0)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FIGlet fonts for the name banner, from the FIGlet distribution
+//
+//go:embed fonts/*.flf
+var fontFiles embed.FS
+
+// Embedded fonts, tallest first, so the first one that fits is the largest
+var fonts = mustLoadFonts()
+
+// A FIGlet font (.flf): each character is drawn with height rows of
+// sub-characters, and characters are pushed together according to the
+// font's layout
+type figFont struct {
+	name      string
+	height    int
+	hardblank rune // shown as a space, but never overlapped
+	layout    int  // fullWidth, kerning or smushing
+	rules     int  // smushing rules, none meaning universal smushing
+	chars     map[rune][]string
+}
+
+// Horizontal layouts
+const (
+	fullWidth = iota // characters side by side
+	kerning          // moved together until they touch
+	smushing         // moved one column further, merging the touching pair
+)
+
+// Controlled smushing rules, as numbered in the FIGlet font format
+const (
+	smushEqual     = 1  // two identical characters
+	smushUnderline = 2  // _ gives way to |/\[]{}()<>
+	smushHierarchy = 4  // the higher class of | /\ [] {} () <> wins
+	smushPair      = 8  // opposite brackets become |
+	smushBigX      = 16 // /\ becomes |, \/ Y and >< X
+	smushHardblank = 32 // two hardblanks
+)
+
+func mustLoadFonts() []*figFont {
+	files, _ := fontFiles.ReadDir("fonts")
+
+	var fonts []*figFont
+	for _, file := range files {
+		src, _ := fontFiles.ReadFile("fonts/" + file.Name())
+		f, err := parseFont(strings.TrimSuffix(file.Name(), ".flf"), src)
+		if err != nil {
+			panic(fmt.Sprintf("figlet: fonts/%s: %v", file.Name(), err))
+		}
+		fonts = append(fonts, f)
+	}
+	slices.SortStableFunc(fonts, func(a, b *figFont) int {
+		return b.height - a.height
+	})
+	return fonts
+}
+
+// Finds an embedded font by name, or loads a .flf file
+func findFont(name string) (*figFont, error) {
+	if strings.HasSuffix(name, ".flf") {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		return parseFont(strings.TrimSuffix(path.Base(name), ".flf"), src)
+	}
+	for _, f := range fonts {
+		if f.name == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown font %q (available: %s, or a .flf file)", name, strings.Join(fontNames(), ", "))
+}
+
+func fontNames() []string {
+	names := make([]string, len(fonts))
+	for i, f := range fonts {
+		names[i] = f.name
+	}
+	return names
+}
+
+// Parses a font in the flf2a format: a header line, comment lines, the
+// ASCII characters 32-126 and seven German letters in a fixed order, then
+// further characters each introduced by its code
+func parseFont(name string, src []byte) (*figFont, error) {
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	if !sc.Scan() {
+		return nil, fmt.Errorf("empty font")
+	}
+	header := strings.Fields(sc.Text())
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) < 6 {
+		return nil, fmt.Errorf("not a FIGlet font")
+	}
+	nums := make([]int, len(header)-1)
+	for i, field := range header[1:] {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("bad header field %q", field)
+		}
+		nums[i] = n
+	}
+
+	f := &figFont{
+		name:      name,
+		height:    nums[0],
+		hardblank: []rune(header[0])[5],
+		chars:     map[rune][]string{},
+	}
+	if f.height < 1 {
+		return nil, fmt.Errorf("bad height %d", f.height)
+	}
+
+	// The full layout, when given, supersedes the old one
+	oldLayout := nums[3]
+	switch {
+	case len(nums) >= 7:
+		full := nums[6]
+		switch {
+		case full&128 != 0:
+			f.layout, f.rules = smushing, full&63
+		case full&64 != 0:
+			f.layout = kerning
+		default:
+			f.layout = fullWidth
+		}
+	case oldLayout < 0:
+		f.layout = fullWidth
+	case oldLayout == 0:
+		f.layout = kerning
+	default:
+		f.layout, f.rules = smushing, oldLayout&63
+	}
+
+	for i := 0; i < nums[4]; i++ {
+		sc.Scan() // comment
+	}
+
+	readChar := func() ([]string, error) {
+		rows := make([]string, f.height)
+		for i := range rows {
+			if !sc.Scan() {
+				return nil, fmt.Errorf("truncated character")
+			}
+			// Rows end with an end mark, doubled on the last row
+			row := strings.TrimRight(sc.Text(), " \r")
+			if row != "" {
+				row = strings.TrimRight(row, string(row[len(row)-1]))
+			}
+			rows[i] = row
+		}
+		return rows, nil
+	}
+
+	for c := rune(32); c <= 126; c++ {
+		rows, err := readChar()
+		if err != nil {
+			return nil, fmt.Errorf("character %q: %v", c, err)
+		}
+		f.chars[c] = rows
+	}
+	for _, c := range []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'} {
+		rows, err := readChar()
+		if err != nil {
+			break // older fonts stop after ASCII
+		}
+		f.chars[c] = rows
+	}
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil {
+			break
+		}
+		rows, err := readChar()
+		if err != nil {
+			return nil, fmt.Errorf("character %d: %v", code, err)
+		}
+		if code >= 0 {
+			f.chars[rune(code)] = rows
+		}
+	}
+	return f, nil
+}
+
+// Renders text, leaving out characters the font lacks
+func (f *figFont) render(text string) []string {
+	lines := make([][]rune, f.height)
+	prevWidth := 0
+	for _, c := range text {
+		char, ok := f.chars[c]
+		if !ok {
+			continue
+		}
+		rows := make([][]rune, f.height)
+		width := 0
+		for i, row := range char {
+			rows[i] = []rune(row)
+			width = max(width, len(rows[i]))
+		}
+
+		overlap := f.overlap(lines, rows, prevWidth, width)
+		for i := range lines {
+			line, row := lines[i], rows[i]
+			for k := 0; k < overlap && k < len(row); k++ {
+				col := len(line) - overlap + k
+				if col >= 0 {
+					line[col] = f.smush(line[col], row[k], prevWidth, width)
+				}
+			}
+			if overlap < len(row) {
+				line = append(line, row[overlap:]...)
+			}
+			lines[i] = line
+		}
+		prevWidth = width
+	}
+
+	out := make([]string, f.height)
+	for i, line := range lines {
+		out[i] = strings.ReplaceAll(string(line), string(f.hardblank), " ")
+	}
+	// Rows may differ in length; pad them so the banner is a rectangle
+	width := 0
+	for _, line := range out {
+		width = max(width, len([]rune(line)))
+	}
+	for i, line := range out {
+		out[i] = line + strings.Repeat(" ", width-len([]rune(line)))
+	}
+	return out
+}
+
+// Columns by which the next character can overlap the end of the lines:
+// the blanks between them on the closest row, plus one when the touching
+// pair smushes
+func (f *figFont) overlap(lines, rows [][]rune, prevWidth, width int) int {
+	if f.layout == fullWidth {
+		return 0
+	}
+	amount := width
+	for i := range lines {
+		line, row := lines[i], rows[i]
+		lineEnd := len(line) - 1
+		for lineEnd >= 0 && line[lineEnd] == ' ' {
+			lineEnd--
+		}
+		rowStart := 0
+		for rowStart < len(row) && row[rowStart] == ' ' {
+			rowStart++
+		}
+
+		n := rowStart + len(line) - 1 - lineEnd
+		if lineEnd >= 0 && rowStart < len(row) && f.smush(line[lineEnd], row[rowStart], prevWidth, width) != 0 {
+			n++
+		}
+		amount = min(amount, n)
+	}
+	return amount
+}
+
+// The character two overlapping ones merge into, or 0 when they don't
+func (f *figFont) smush(left, right rune, leftWidth, rightWidth int) rune {
+	switch {
+	case left == ' ':
+		return right
+	case right == ' ':
+		return left
+	case f.layout != smushing || leftWidth < 2 || rightWidth < 2:
+		return 0
+	}
+
+	if f.rules == 0 {
+		// Universal smushing: the right character wins over all but hardblanks
+		switch {
+		case left == f.hardblank:
+			return right
+		case right == f.hardblank:
+			return left
+		}
+		return right
+	}
+
+	if left == f.hardblank || right == f.hardblank {
+		if f.rules&smushHardblank != 0 && left == right {
+			return left
+		}
+		return 0
+	}
+	if f.rules&smushEqual != 0 && left == right {
+		return left
+	}
+	if f.rules&smushUnderline != 0 {
+		switch {
+		case left == '_' && strings.ContainsRune(`|/\[]{}()<>`, right):
+			return right
+		case right == '_' && strings.ContainsRune(`|/\[]{}()<>`, left):
+			return left
+		}
+	}
+	if f.rules&smushHierarchy != 0 {
+		classes := []string{"|", `/\`, "[]", "{}", "()", "<>"}
+		l := slices.IndexFunc(classes, func(c string) bool { return strings.ContainsRune(c, left) })
+		r := slices.IndexFunc(classes, func(c string) bool { return strings.ContainsRune(c, right) })
+		switch {
+		case l >= 0 && r >= 0 && l < r:
+			return right
+		case l >= 0 && r >= 0 && l > r:
+			return left
+		}
+	}
+	if f.rules&smushPair != 0 {
+		switch string([]rune{left, right}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|'
+		}
+	}
+	if f.rules&smushBigX != 0 {
+		switch string([]rune{left, right}) {
+		case `/\`:
+			return '|'
+		case `\/`:
+			return 'Y'
+		case "><":
+			return 'X'
+		}
+	}
+	return 0
+}
+
+// Font chosen in the config for the banner; nil picks the largest that fits
+var bannerFont *figFont
+
+// The owner's name as a banner no wider than width: in the chosen font, or
+// the largest embedded one that fits, or as bold text when none does
+func (m model) banner(width int) string {
+	candidates := fonts
+	if bannerFont != nil {
+		candidates = []*figFont{bannerFont}
+	}
+	for _, f := range candidates {
+		rows := f.render(owner.Name)
+		// Rows kept free for descenders are of no use to a name
+		for len(rows) > 0 && strings.TrimSpace(rows[len(rows)-1]) == "" {
+			rows = rows[:len(rows)-1]
+		}
+		if len(rows) > 0 && len([]rune(rows[0])) <= width {
+			return m.styles.title.Render(strings.Join(rows, "\n"))
+		}
+	}
+	return m.styles.title.Bold(true).Render(owner.Name)
+}
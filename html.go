@@ -0,0 +1,151 @@
+package main
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// A standalone page: the styles are inline and the links the only requests
+var htmlPage = template.Must(template.New("resume").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}" dir="{{.Dir}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}} — {{.Subtitle}}</title>
+<style>
+body { max-width: 48rem; margin: 2rem auto; padding: 0 1rem; font: 15px/1.5 system-ui, sans-serif; color: {{.Colors.Text}}; }
+h1 { margin: 0; color: {{.Colors.Accent}}; }
+h2 { margin-top: 2rem; color: {{.Colors.Accent}}; border-bottom: 1px solid {{.Colors.Rule}}; text-transform: uppercase; font-size: 1.1rem; }
+h3 { margin: 1.25rem 0 0.25rem; font-size: 1rem; }
+a { color: {{.Colors.Accent}}; }
+p { margin: 0.25rem 0; }
+.subtitle { font-size: 1.15rem; margin: 0.25rem 0; }
+.meta, .contact { color: {{.Colors.Muted}}; }
+.meta { font-style: italic; }
+.org { font-weight: normal; color: {{.Colors.Accent}}; }
+.tags { display: inline; list-style: none; padding: 0; }
+.tags li { display: inline-block; margin: 0.1rem 0.15rem; padding: 0 0.4rem; border-radius: 0.25rem; background: {{.Colors.Tag}}; color: {{.Colors.Accent}}; font-size: 0.85rem; }
+dl { display: grid; grid-template-columns: max-content 1fr; gap: 0.25rem 1rem; }
+dt { font-weight: bold; }
+dd { margin: 0; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Name}}</h1>
+<p class="subtitle">{{.Subtitle}}</p>
+<p class="contact">{{range $i, $l := .Contact}}{{if $i}} · {{end}}{{if $l.URL}}<a href="{{$l.URL}}">{{$l.Text}}</a>{{else}}{{$l.Text}}{{end}}{{end}}</p>
+</header>
+<section>
+<h2>{{.BioTitle}}</h2>
+{{- range .Bio}}
+<p>{{.}}</p>
+{{- end}}
+</section>
+{{- range .Sections}}
+<section>
+<h2>{{.Title}}</h2>
+{{- if .Compact}}
+<dl>
+{{- range .Entries}}
+<dt>{{.Title}}</dt>
+<dd><ul class="tags">{{range (index .Blocks 0).Tags}}<li>{{.}}</li>{{end}}</ul></dd>
+{{- end}}
+</dl>
+{{- else}}
+{{- range .Entries}}
+<article>
+<h3>{{if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}{{if .Org}} <span class="org">— {{.Org}}</span>{{end}}</h3>
+{{- range .Blocks}}
+{{- if .Meta}}
+<p class="meta">{{.Text}}</p>
+{{- else if .Tags}}
+<div>{{if .Label}}<strong>{{.Label}}</strong> {{end}}<ul class="tags">{{range .Tags}}<li>{{.}}</li>{{end}}</ul></div>
+{{- else}}
+<p>{{if .Label}}<strong>{{.Label}}</strong> {{end}}{{.Text}}</p>
+{{- end}}
+{{- end}}
+</article>
+{{- end}}
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// The resume as the page template reads it
+type htmlResume struct {
+	Lang, Dir, Name, Subtitle, BioTitle string
+	Colors                              struct{ Text, Accent, Rule, Muted, Tag template.CSS }
+	Contact                             []htmlLink
+	Bio                                 []string
+	Sections                            []htmlSection
+}
+
+// Links are the resume's own, so tel: and mailto: URLs are let through
+type htmlLink struct {
+	URL  template.URL
+	Text string
+}
+
+type htmlSection struct {
+	Title   string
+	Compact bool
+	Entries []htmlEntry
+}
+
+type htmlEntry struct {
+	Title, Org string
+	URL        template.URL
+	Blocks     []htmlBlock
+}
+
+type htmlBlock struct {
+	Meta        bool
+	Label, Text string
+	Tags        []string
+}
+
+// Writes the resume as a standalone web page (export --format html) in the
+// theme's colors
+func writeHTML(w io.Writer, o exportOptions) error {
+	m := o.model()
+	r := htmlResume{
+		Lang:     string(o.lang),
+		Dir:      "ltr",
+		Name:     owner.Name,
+		Subtitle: m.t("subtitle"),
+		BioTitle: m.t("bio_title"),
+	}
+	if m.rtl() {
+		r.Dir = "rtl"
+	}
+	c := newPaperColors(themes[m.theme].Colors)
+	r.Colors.Text = template.CSS(c.text.Hex())
+	r.Colors.Accent = template.CSS(c.accent.Hex())
+	r.Colors.Rule = template.CSS(c.rule.Hex())
+	r.Colors.Muted = template.CSS(c.muted.Hex())
+	r.Colors.Tag = template.CSS(c.tag.Hex())
+
+	for _, l := range m.contactDetails() {
+		r.Contact = append(r.Contact, htmlLink{template.URL(l.url), l.text})
+	}
+	for _, paragraph := range m.bio() {
+		r.Bio = append(r.Bio, strings.Join(paragraph, " "))
+	}
+	for _, s := range m.resume() {
+		section := htmlSection{Title: s.title, Compact: s.compact}
+		for _, e := range s.entries {
+			entry := htmlEntry{Title: e.title, Org: e.org, URL: template.URL(e.url)}
+			for _, b := range e.blocks {
+				entry.Blocks = append(entry.Blocks, htmlBlock{Meta: b.kind == blockMeta, Label: b.label, Text: b.text(), Tags: b.tags})
+			}
+			section.Entries = append(section.Entries, entry)
+		}
+		r.Sections = append(r.Sections, section)
+	}
+
+	return htmlPage.Execute(w, r)
+}
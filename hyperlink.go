@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// Values of TERM_PROGRAM set by terminals that open OSC 8 hyperlinks
+var hyperlinkPrograms = []string{"iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "Tabby", "rio", "WarpTerminal"}
+
+// TERM of terminals that open OSC 8 hyperlinks
+var hyperlinkTerms = []string{"xterm-kitty", "alacritty", "foot", "foot-extra", "wezterm", "xterm-ghostty", "contour"}
+
+// Hyperlinks setting from the config: nil detects support per terminal
+var hyperlinksSetting *bool
+
+// Whether a terminal opens OSC 8 hyperlinks, from its TERM and the variables
+// terminals set: TERM_PROGRAM, VTE_VERSION (GNOME Terminal and other VTE
+// terminals since 0.50), KONSOLE_VERSION (since 20.08) and WT_SESSION
+// (Windows Terminal). Over SSH only those the client sends are known, so
+// unknown terminals get footnotes.
+func hyperlinkTerminal(term string, environ []string) bool {
+	if hyperlinksSetting != nil {
+		return *hyperlinksSetting
+	}
+
+	env := map[string]string{}
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	version := func(key string) int {
+		n, _ := strconv.Atoi(env[key])
+		return n
+	}
+
+	for _, t := range hyperlinkTerms {
+		if term == t {
+			return true
+		}
+	}
+	for _, p := range hyperlinkPrograms {
+		if env["TERM_PROGRAM"] == p {
+			return true
+		}
+	}
+	return version("VTE_VERSION") >= 5000 || version("KONSOLE_VERSION") >= 200800 || env["WT_SESSION"] != ""
+}
+
+// OSC 8 hyperlinks as written by link
+var linkPattern = regexp.MustCompile("\x1b]8;;([^\x07]*)\x07(.*?)\x1b]8;;\x07")
+
+// Replaces the hyperlinks of lines by their text and a footnote mark,
+// numbered after the URLs already collected, and collects their URLs
+func footnotes(lines []string, urls *[]string) []string {
+	marked := make([]string, len(lines))
+	for i, line := range lines {
+		marked[i] = linkPattern.ReplaceAllStringFunc(line, func(l string) string {
+			match := linkPattern.FindStringSubmatch(l)
+			*urls = append(*urls, match[1])
+			return fmt.Sprintf("%s [%d]", match[2], len(*urls))
+		})
+	}
+	return marked
+}
+
+// Hyperlink whose text is the address itself, as in the contact details:
+// plain text on terminals that can't open hyperlinks
+func (m model) link(url, text string) string {
+	if !m.hyperlinks {
+		return text
+	}
+	return link(url, text)
+}
+
+// Right panel content of a tab. Without hyperlinks, links get footnote
+// marks and their URLs are listed in a last entry.
+func (m model) tabContent(tab int) tabContent {
+	c := m.tabEntries(tab)
+	if f, ok := m.focusedLink(); ok {
+		for i, e := range c.entries {
+			for j, line := range e.lines {
+				c.entries[i].lines[j] = strings.ReplaceAll(line, link(f.url, f.text), link(f.url, m.markFocus(f)))
+			}
+		}
+	}
+	if m.hyperlinks {
+		return c
+	}
+
+	var urls []string
+	for i, e := range c.entries {
+		c.entries[i].lines = footnotes(e.lines, &urls)
+	}
+	if len(urls) == 0 {
+		return c
+	}
+	lines := []string{m.entryTitle(m.t("links_title"))}
+	for i, url := range urls {
+		lines = append(lines, m.styles.muted.Render(fmt.Sprintf("[%d]", i+1))+" "+m.styles.content.Render(url))
+	}
+	c.entries = append(c.entries, entry{m.t("links_title"), lines})
+	return c
+}
+
+// A link that n/N can focus and y copy
+type focusLink struct {
+	url  string
+	text string
+}
+
+// Links of the contact block, from the resume
+func contactLinks() []focusLink {
+	return []focusLink{
+		{"mailto:" + owner.Email, owner.Email},
+		{"https://linkedin.com/in/" + owner.LinkedIn, "linkedin.com/in/" + owner.LinkedIn},
+		{"https://github.com/" + owner.GitHub, "github.com/" + owner.GitHub},
+	}
+}
+
+// Links n/N moves through: the contact block's, then the current tab's
+func (m model) focusLinks() []focusLink {
+	links := contactLinks()
+	for _, e := range m.tabEntries(m.cursor).entries {
+		for _, line := range e.lines {
+			for _, match := range linkPattern.FindAllStringSubmatch(line, -1) {
+				links = append(links, focusLink{match[1], match[2]})
+			}
+		}
+	}
+	return links
+}
+
+// The focused link, if any
+func (m model) focusedLink() (focusLink, bool) {
+	links := m.focusLinks()
+	if m.focused < 1 || m.focused > len(links) {
+		return focusLink{}, false
+	}
+	return links[m.focused-1], true
+}
+
+// Moves the focus to the next (step 1) or previous (step -1) link, scrolling
+// a link of the tab into view
+func (m *model) focusLink(step int) {
+	links := m.focusLinks()
+	switch {
+	case m.focused == 0 && step < 0:
+		m.focused = len(links)
+	case m.focused == 0:
+		m.focused = 1
+	default:
+		n := len(links)
+		m.focused = ((m.focused-1+step)%n+n)%n + 1
+	}
+
+	f := links[m.focused-1]
+	l := m.layout()
+	_, starts := renderPanel(m.tabContent(m.cursor), l.rightPanelWidth)
+	for i, e := range m.tabEntries(m.cursor).entries {
+		if !strings.Contains(strings.Join(e.lines, "\n"), link(f.url, f.text)) {
+			continue
+		}
+		if starts[i] < m.scroll || starts[i] >= m.scroll+l.contentHeight {
+			m.scroll = min(starts[i], lastMaxScroll)
+		}
+		break
+	}
+}
+
+// Marks the text of the focused link: reverse video, or asterisks on
+// terminals without attributes
+func (m model) markFocus(l focusLink) string {
+	if f, ok := m.focusedLink(); !ok || f != l {
+		return l.text
+	}
+	if m.renderer.ColorProfile() == termenv.Ascii {
+		return "*" + l.text + "*"
+	}
+	// Only toggles reverse video, keeping the surrounding style
+	return "\x1b[7m" + l.text + "\x1b[27m"
+}
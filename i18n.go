@@ -0,0 +1,454 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	"termfolio/data"
+)
+
+// Message catalogs, one locales/<code>.json per registered language, mapping
+// keys to ICU MessageFormat strings
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// A parsed catalog: key -> message
+type catalog map[string]message
+
+var catalogs = mustLoadCatalogs()
+
+// Resume owner, whose gender picks the wording of gendered messages
+var owner = data.GetResume().Contact
+
+// Years of experience quoted in the biography ("nearly 3 years")
+const experienceYears = 3
+
+// Arguments for a message, e.g. args{"years": 3}
+type args map[string]any
+
+func mustLoadCatalogs() map[Lang]catalog {
+	catalogs := map[Lang]catalog{}
+	for _, l := range languages {
+		messages, _, err := catalogSource(l.lang)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		c := catalog{}
+		for key, src := range messages {
+			msg, err := parseMessage(src)
+			if err != nil {
+				panic(fmt.Sprintf("i18n: locales/%s.json: %s: %v", l.lang, key, err))
+			}
+			c[key] = msg
+		}
+		catalogs[l.lang] = c
+	}
+	return catalogs
+}
+
+// Unparsed messages of a language's catalog, and its keys in file order
+func catalogSource(lang Lang) (map[string]string, []string, error) {
+	file := "locales/" + string(lang) + ".json"
+	raw, err := localeFiles.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("i18n: no catalog for %s: %v", lang, err)
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil, nil, fmt.Errorf("i18n: %s: %v", file, err)
+	}
+
+	// Object keys come back in order from the token stream
+	var keys []string
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.Token() // {
+	for dec.More() {
+		key, _ := dec.Token()
+		dec.Token() // value
+		keys = append(keys, key.(string))
+	}
+	return messages, keys, nil
+}
+
+// Helper to get translation
+func (m model) t(key string) string {
+	return m.tf(key, nil)
+}
+
+// Translation with arguments. The owner's gender and years of experience
+// are always available; a key missing from the current language falls back
+// to English, then to the key itself.
+func (m model) tf(key string, a args) string {
+	if m.usedKeys != nil {
+		m.usedKeys[key] = true
+	}
+
+	values := args{"gender": owner.Gender, "years": experienceYears}
+	maps.Copy(values, a)
+
+	for _, lang := range []Lang{m.lang, EN} {
+		if msg, ok := catalogs[lang][key]; ok {
+			return msg.format(lang, values)
+		}
+	}
+	return key
+}
+
+// Keys used while rendering every screen in every language, with hyperlinks
+// and with footnotes, and the copy toasts, that are missing from a catalog,
+// and catalog keys that nothing uses
+func catalogReport() (missing, unused []string) {
+	used := map[string]bool{}
+	for _, l := range languages {
+		for _, hyperlinks := range []bool{true, false} {
+			m := initialModel(l.lang)
+			m.usedKeys = used
+			m.hyperlinks = hyperlinks
+			for _, screen := range m.screens() {
+				screen.View()
+			}
+			m.copyMessage("", true)
+			m.copyMessage("", false)
+		}
+	}
+
+	for _, l := range languages {
+		for _, key := range slices.Sorted(maps.Keys(used)) {
+			if _, ok := catalogs[l.lang][key]; !ok {
+				missing = append(missing, fmt.Sprintf("%s: missing %q", l.lang, key))
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(catalogs[l.lang])) {
+			if !used[key] {
+				unused = append(unused, fmt.Sprintf("%s: unused %q", l.lang, key))
+			}
+		}
+	}
+	return missing, unused
+}
+
+// Every distinct screen of a model: the welcome screen, each tab, the
+// language picker and the QR view
+func (m model) screens() []model {
+	welcome := m
+	welcome.screen = WelcomeScreen
+
+	screens := []model{welcome}
+	m.screen = PortfolioScreen
+	for tab := range m.tabTitles() {
+		m.cursor = tab
+		screens = append(screens, m)
+	}
+	picker := m
+	picker.openPicker()
+	m.openQR()
+	return append(screens, picker, m)
+}
+
+// A message in a subset of ICU MessageFormat: literal text, {arg},
+// {arg, number}, {arg, select, ...} and {arg, plural, ...} with # and =N
+// cases. An apostrophe quotes syntax characters, as in '{', and a doubled
+// apostrophe stands for a literal one.
+type message []part
+
+type part struct {
+	text string // literal text
+
+	arg    string             // argument name
+	kind   string             // "", "number", "select" or "plural"
+	offset int                // plural offset
+	cases  map[string]message // select or plural cases
+
+	hash bool // # in a plural case
+}
+
+type msgParser struct {
+	src []rune
+	pos int
+}
+
+func parseMessage(s string) (message, error) {
+	p := &msgParser{src: []rune(s)}
+	msg, err := p.parse(false, false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected '}' at %d", p.pos)
+	}
+	return msg, nil
+}
+
+// Parses text up to the end of input, or up to the '}' closing a nested
+// case. Inside plural cases, # stands for the number.
+func (p *msgParser) parse(nested, inPlural bool) (message, error) {
+	var msg message
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			msg = append(msg, part{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == '\'':
+			p.pos++
+			switch {
+			case p.pos < len(p.src) && p.src[p.pos] == '\'':
+				text.WriteRune('\'')
+				p.pos++
+			case p.pos < len(p.src) && (p.src[p.pos] == '{' || p.src[p.pos] == '}' || (inPlural && p.src[p.pos] == '#')):
+				// Quoted literal up to the next lone apostrophe
+				for p.pos < len(p.src) {
+					if p.src[p.pos] == '\'' {
+						if p.pos+1 < len(p.src) && p.src[p.pos+1] == '\'' {
+							text.WriteRune('\'')
+							p.pos += 2
+							continue
+						}
+						p.pos++
+						break
+					}
+					text.WriteRune(p.src[p.pos])
+					p.pos++
+				}
+			default:
+				text.WriteRune('\'')
+			}
+		case c == '{':
+			flush()
+			arg, err := p.parseArg()
+			if err != nil {
+				return nil, err
+			}
+			msg = append(msg, arg)
+		case c == '}':
+			if !nested {
+				return nil, fmt.Errorf("unexpected '}' at %d", p.pos)
+			}
+			flush()
+			return msg, nil
+		case c == '#' && inPlural:
+			flush()
+			msg = append(msg, part{hash: true})
+			p.pos++
+		default:
+			text.WriteRune(c)
+			p.pos++
+		}
+	}
+	if nested {
+		return nil, fmt.Errorf("unterminated case")
+	}
+	flush()
+	return msg, nil
+}
+
+// Parses an argument starting at its '{'
+func (p *msgParser) parseArg() (part, error) {
+	p.pos++ // {
+	name := p.token()
+	if name == "" {
+		return part{}, fmt.Errorf("missing argument name at %d", p.pos)
+	}
+	arg := part{arg: name}
+
+	p.skipSpace()
+	if p.accept('}') {
+		return arg, nil
+	}
+	if !p.accept(',') {
+		return part{}, fmt.Errorf("expected ',' or '}' after %q", name)
+	}
+
+	arg.kind = p.token()
+	p.skipSpace()
+	switch arg.kind {
+	case "number":
+		if !p.accept('}') {
+			return part{}, fmt.Errorf("expected '}' after %q", name)
+		}
+		return arg, nil
+	case "select", "plural":
+	default:
+		return part{}, fmt.Errorf("unknown argument type %q", arg.kind)
+	}
+	if !p.accept(',') {
+		return part{}, fmt.Errorf("expected ',' after %s", arg.kind)
+	}
+
+	arg.cases = map[string]message{}
+	for {
+		p.skipSpace()
+		if p.accept('}') {
+			break
+		}
+
+		selector := p.token()
+		if arg.kind == "plural" && strings.HasPrefix(selector, "offset:") {
+			offset, err := strconv.Atoi(strings.TrimPrefix(selector, "offset:"))
+			if err != nil {
+				return part{}, fmt.Errorf("bad plural offset %q", selector)
+			}
+			arg.offset = offset
+			continue
+		}
+		if selector == "" {
+			return part{}, fmt.Errorf("missing case selector in %q", name)
+		}
+
+		p.skipSpace()
+		if !p.accept('{') {
+			return part{}, fmt.Errorf("expected '{' after case %q", selector)
+		}
+		msg, err := p.parse(true, arg.kind == "plural")
+		if err != nil {
+			return part{}, err
+		}
+		p.pos++ // }
+		arg.cases[selector] = msg
+	}
+
+	if _, ok := arg.cases["other"]; !ok {
+		return part{}, fmt.Errorf("%s %q has no other case", arg.kind, name)
+	}
+	return arg, nil
+}
+
+// Reads a run of characters up to whitespace or syntax
+func (p *msgParser) token() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\n,{}", p.src[p.pos]) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+func (p *msgParser) skipSpace() {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\n", p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *msgParser) accept(c rune) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (msg message) format(lang Lang, values args) string {
+	var b strings.Builder
+	msg.write(&b, lang, values, "")
+	return b.String()
+}
+
+// Writes the message; number is what # stands for inside a plural case
+func (msg message) write(b *strings.Builder, lang Lang, values args, number string) {
+	for _, p := range msg {
+		switch {
+		case p.hash:
+			b.WriteString(number)
+		case p.arg == "":
+			b.WriteString(p.text)
+		case p.kind == "select":
+			c, ok := p.cases[fmt.Sprint(values[p.arg])]
+			if !ok {
+				c = p.cases["other"]
+			}
+			c.write(b, lang, values, number)
+		case p.kind == "plural":
+			v, given := values[p.arg]
+			n, ok := pluralNumber(v)
+			if !ok {
+				// No case can be picked: shown like a missing argument
+				if given {
+					log.Printf("i18n: plural {%s} needs an integer, got %v (%T)", p.arg, v, v)
+				}
+				b.WriteString("{" + p.arg + "}")
+				continue
+			}
+			c, ok := p.cases["="+strconv.Itoa(n)]
+			if !ok {
+				c, ok = p.cases[pluralCategory(lang, n-p.offset)]
+			}
+			if !ok {
+				c = p.cases["other"]
+			}
+			c.write(b, lang, values, strconv.Itoa(n-p.offset))
+		default:
+			if v, ok := values[p.arg]; ok {
+				b.WriteString(fmt.Sprint(v))
+			} else {
+				b.WriteString("{" + p.arg + "}")
+			}
+		}
+	}
+}
+
+// The integer a plural argument stands for; other values have no plural
+// form
+func pluralNumber(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// CLDR plural category of an integer in a language: zero, one, two, few,
+// many or other
+func pluralCategory(lang Lang, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch plural.Cardinal.MatchPlural(language.Make(string(lang)), n, 0, 0, 0, 0) {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	}
+	return "other"
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr bool
+	}{
+		{src: "plain text"},
+		{src: "Hello {name}"},
+		{src: "{n, number} items"},
+		{src: "{gender, select, female {Elle} other {Il}}"},
+		{src: "{n, plural, =0 {none} one {# item} other {# items}}"},
+		{src: "{n, plural, offset:1 =0 {nobody} other {you and # others}}"},
+		{src: "'{'quoted'}' and it''s"},
+		{src: "{", wantErr: true},
+		{src: "{}", wantErr: true},
+		{src: "text}", wantErr: true},
+		{src: "{n, date}", wantErr: true},
+		{src: "{n number}", wantErr: true},
+		{src: "{n, plural, one {# item}}", wantErr: true},
+		{src: "{n, plural, offset:x other {#}}", wantErr: true},
+		{src: "{g, select, male {Il} other {Elle}", wantErr: true},
+		{src: "{g, select, male Il other {Elle}}", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, err := parseMessage(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMessage(%q) error = %v, want error %v", tt.src, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	tests := []struct {
+		lang   Lang
+		src    string
+		values args
+		want   string
+	}{
+		{EN, "Hello {name}", args{"name": "Ada"}, "Hello Ada"},
+		{EN, "Hello {name}", args{}, "Hello {name}"},
+		{EN, "'{'name'}' isn''t {name}", args{"name": "Ada"}, "{name} isn't Ada"},
+		{EN, "{n, number} items", args{"n": 3}, "3 items"},
+
+		// select
+		{FR, "{gender, select, female {Ingénieure} other {Ingénieur}}", args{"gender": "female"}, "Ingénieure"},
+		{FR, "{gender, select, female {Ingénieure} other {Ingénieur}}", args{"gender": "male"}, "Ingénieur"},
+		{FR, "{gender, select, female {Ingénieure} other {Ingénieur}}", args{}, "Ingénieur"},
+
+		// plural, with the language's categories
+		{EN, "{n, plural, one {# year} other {# years}}", args{"n": 1}, "1 year"},
+		{EN, "{n, plural, one {# year} other {# years}}", args{"n": 0}, "0 years"},
+		{EN, "{n, plural, one {# year} other {# years}}", args{"n": 3}, "3 years"},
+		{FR, "{n, plural, one {# an} other {# ans}}", args{"n": 0}, "0 an"},
+		{FR, "{n, plural, one {# an} other {# ans}}", args{"n": 2}, "2 ans"},
+		{AR, "{n, plural, zero {صفر} one {واحد} two {اثنان} few {# قليل} many {# كثير} other {#}}", args{"n": 2}, "اثنان"},
+		{AR, "{n, plural, zero {صفر} one {واحد} two {اثنان} few {# قليل} many {# كثير} other {#}}", args{"n": 5}, "5 قليل"},
+		{AR, "{n, plural, zero {صفر} one {واحد} two {اثنان} few {# قليل} many {# كثير} other {#}}", args{"n": 11}, "11 كثير"},
+		{EN, "{n, plural, =0 {none} one {# item} other {# items}}", args{"n": 0}, "none"},
+		{EN, "{n, plural, offset:1 =0 {nobody} one {you and # other} other {you and # others}}", args{"n": 2}, "you and 1 other"},
+		{EN, "{n, plural, one {# year} other {# years}}", args{"n": int64(1)}, "1 year"},
+
+		// A plural of something other than an integer has no case to pick
+		{EN, "{n, plural, one {# year} other {# years}}", args{"n": "three"}, "{n}"},
+		{EN, "{n, plural, one {# year} other {# years}}", args{"n": 2.5}, "{n}"},
+		{EN, "{n, plural, one {# year} other {# years}}", args{}, "{n}"},
+	}
+	for _, tt := range tests {
+		msg, err := parseMessage(tt.src)
+		if err != nil {
+			t.Errorf("parseMessage(%q): %v", tt.src, err)
+			continue
+		}
+		if got := msg.format(tt.lang, tt.values); got != tt.want {
+			t.Errorf("%s: format(%q, %v) = %q, want %q", tt.lang, tt.src, tt.values, got, tt.want)
+		}
+	}
+}
+
+// The years of experience take the plural forms of each language: Arabic
+// has a dual, and its own forms from 3 to 10 and from 11 to 99
+func TestExperiencePlural(t *testing.T) {
+	tests := []struct {
+		lang  Lang
+		years int
+		want  string
+	}{
+		{EN, 1, "1 year "},
+		{EN, 2, "2 years "},
+		{FR, 1, "1 an "},
+		{FR, 2, "2 ans "},
+		{ES, 2, "2 años "},
+		{AR, 1, "سنة واحدة"},
+		{AR, 2, "سنتين"},
+		{AR, 3, "3 سنوات"},
+		{AR, 11, "11 سنة"},
+		{AR, 100, "100 سنة"},
+	}
+	for _, tt := range tests {
+		got := catalogs[tt.lang]["about_1"].format(tt.lang, args{"years": tt.years})
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: about_1 with %d years = %q, want it to contain %q", tt.lang, tt.years, got, tt.want)
+		}
+	}
+}
+
+// What termfolio i18n check reports: keys a catalog lacks, and English
+// words left on translated screens. Keys no screen uses are only logged.
+func TestTranslations(t *testing.T) {
+	problems, unused := checkTranslations()
+	for _, problem := range problems {
+		t.Errorf("i18n: %s", problem)
+	}
+	for _, problem := range unused {
+		t.Logf("i18n: %s", problem)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Terms shown as is in every language, so the same word on the English and
+// a translated screen isn't a missed translation: technologies, companies,
+// projects and other proper nouns written in code rather than catalogs
+var glossary = []string{
+	"Ansible", "asyncio", "AWS EC2", "Azure ML", "Bash", "Beautiful Soup",
+	"CI/CD", "Dart", "Django", "Docker", "DRF", "Etifak", "F#", "FastAPI",
+	"Flutter", "Git", "HTML/CSS", "Java", "JavaFX", "JavaScript", "Linux",
+	"Lipgloss", "MealPass", "MongoDB", "NeoShape", "NewMoodle", "NextJS",
+	"Nexus", "Pandas", "PostgreSQL", "Python", "React.js", "Rust",
+	"Scrum/Agile", "Selenium", "SimplyLovelySetups.com", "SQLAlchemy",
+	"Streamlit", "Suez Digital Solutions", "Suricata", "TypeScript",
+	"WebSocket", "Wish",
+}
+
+// Size the screens are rendered at while checking, tall enough for every
+// tab to show all of its lines
+const (
+	checkWidth  = 160
+	checkHeight = 200
+)
+
+// Everything wrong with the translations: keys a screen uses that a catalog
+// lacks, keys present in one catalog but not another, and words rendered
+// exactly as in English that the language's catalog doesn't contain, i.e.
+// literals that bypass translation. Keys no screen uses are returned apart,
+// being worth a note rather than a failure.
+func checkTranslations() (problems, unused []string) {
+	missing, unused := catalogReport()
+	problems = slices.Concat(missing, catalogDiff())
+	slices.Sort(problems)
+	problems = slices.Compact(problems)
+	return append(problems, untranslatedLiterals()...), unused
+}
+
+// Keys found in some catalog but missing from another
+func catalogDiff() []string {
+	keys := map[string]bool{}
+	for _, c := range catalogs {
+		for key := range c {
+			keys[key] = true
+		}
+	}
+
+	var problems []string
+	for _, l := range languages {
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			if _, ok := catalogs[l.lang][key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing %q", l.lang, key))
+			}
+		}
+	}
+	return problems
+}
+
+// Words of every screen in a translated language that also appear on the
+// same English screen and come neither from its catalog nor the glossary
+func untranslatedLiterals() []string {
+	english := screenWords(EN)
+
+	var problems []string
+	for _, l := range languages {
+		if l.lang == EN {
+			continue
+		}
+		known := catalogWords(l.lang)
+		for i, words := range screenWords(l.lang) {
+			for _, word := range slices.Sorted(maps.Keys(words)) {
+				if english[i][word] && !known[word] {
+					problems = append(problems, fmt.Sprintf("%s: untranslated %q on the %s", l.lang, word, screenName(i)))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// Words displayed on each screen of a language. Link text is left out:
+// addresses and URLs read the same everywhere.
+func screenWords(lang Lang) []map[string]bool {
+	m := initialModel(lang)
+	m.width, m.height = checkWidth, checkHeight
+
+	var screens []map[string]bool
+	for _, screen := range m.screens() {
+		words := map[string]bool{}
+		for _, line := range strings.Split(screen.View(), "\n") {
+			var text strings.Builder
+			for _, c := range parseCells(line) {
+				if c.link != "" {
+					c.r = ' '
+				}
+				text.WriteRune(c.r)
+			}
+			for _, word := range splitWords(text.String()) {
+				words[word] = true
+			}
+		}
+		screens = append(screens, words)
+	}
+	return screens
+}
+
+// Words a language accounts for: those in its catalog, the glossary, the
+// owner's name, and the language codes and native names listed by the
+// selector and picker
+func catalogWords(lang Lang) map[string]bool {
+	known := map[string]bool{}
+	add := func(s string) {
+		for _, word := range splitWords(s) {
+			known[word] = true
+		}
+	}
+
+	messages, _, _ := catalogSource(lang)
+	for _, src := range messages {
+		add(src)
+	}
+	for _, term := range glossary {
+		add(term)
+	}
+	add(owner.Name)
+	for _, l := range languages {
+		add(strings.ToUpper(string(l.lang)) + " " + l.name + " " + visualOrder(l.name))
+	}
+	return known
+}
+
+// Splits text into words of at least two characters containing a letter;
+// '#' and '+' stay attached, as in C# or C++
+func splitWords(s string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '#' && r != '+'
+	}) {
+		if len([]rune(word)) >= 2 && strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Name of the i-th screen returned by model.screens
+func screenName(i int) string {
+	tabs := initialModel(EN).tabTitles()
+	switch {
+	case i == 0:
+		return "welcome screen"
+	case i <= len(tabs):
+		return tabs[i-1] + " tab"
+	case i == len(tabs)+1:
+		return "language picker"
+	}
+	return "QR view"
+}
+
+// termfolio i18n check | review: checks or reviews the translations
+func i18nCommand(args []string) error {
+	if len(args) > 0 && args[0] == "review" {
+		return runReview(args[1:])
+	}
+	if len(args) != 1 || args[0] != "check" {
+		return fmt.Errorf("usage: termfolio i18n check | termfolio i18n review <lang> [<base lang>]")
+	}
+
+	problems, unused := checkTranslations()
+	for _, problem := range slices.Concat(problems, unused) {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d translation problems", len(problems))
+	}
+	fmt.Println("Translations complete")
+	return nil
+}
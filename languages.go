@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Registered interface languages, in selector and Tab order. Adding one
+// takes an entry here and its catalog in locales/<code>.json.
+var languages = []struct {
+	lang Lang
+	name string // native name, shown in the picker
+}{
+	{EN, "English"},
+	{FR, "Français"},
+	{ES, "Español"},
+	{AR, "العربية"},
+}
+
+// Position of a language in the registry, or -1 when it isn't registered
+func langIndex(lang Lang) int {
+	for i, l := range languages {
+		if l.lang == lang {
+			return i
+		}
+	}
+	return -1
+}
+
+// Codes of the registered languages, in registry order
+func langCodes() []string {
+	codes := make([]string, len(languages))
+	for i, l := range languages {
+		codes[i] = string(l.lang)
+	}
+	return codes
+}
+
+// Switches to the next (step 1) or previous (step -1) registered language
+func (m *model) cycleLang(step int) {
+	i := langIndex(m.lang) + step
+	n := len(languages)
+	m.lang = languages[(i%n+n)%n].lang
+}
+
+func (m *model) openPicker() {
+	m.picker = true
+	m.pickerCursor = max(langIndex(m.lang), 0)
+}
+
+// Handles a key while the language picker is open
+func (m *model) updatePicker(key string) {
+	switch key {
+	case "up", "k", "shift+tab":
+		m.pickerCursor = (m.pickerCursor + len(languages) - 1) % len(languages)
+	case "down", "j", "tab":
+		m.pickerCursor = (m.pickerCursor + 1) % len(languages)
+	case "enter", " ":
+		m.lang = languages[m.pickerCursor].lang
+		m.picker = false
+	case "esc", "q", "L":
+		m.picker = false
+	default:
+		// Digits pick a language directly
+		if len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < len(languages) {
+			m.lang = languages[key[0]-'1'].lang
+			m.picker = false
+		}
+	}
+}
+
+// Language picker popup, one row per registered language
+func (m model) pickerView() string {
+	var rows []string
+	for i, l := range languages {
+		row := strings.ToUpper(string(l.lang)) + "  " + visualOrder(l.name)
+		if i == m.pickerCursor {
+			rows = append(rows, m.styles.title.Render("› "+row))
+		} else {
+			rows = append(rows, m.styles.content.Render("  "+row))
+		}
+	}
+
+	return m.renderer.NewStyle().
+		Border(m.styles.box.Border).
+		BorderForeground(m.styles.frame.GetForeground()).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.styles.title.Render(visualOrder(m.t("lang_title"))),
+			"",
+			lipgloss.JoinVertical(lipgloss.Left, rows...),
+		))
+}
+
+// Draws fg over bg with its top left corner at column x, line y
+func overlay(bg, fg string, x, y int) string {
+	lines := strings.Split(bg, "\n")
+	for i, fgLine := range strings.Split(fg, "\n") {
+		if y+i < 0 || y+i >= len(lines) {
+			continue
+		}
+		line := lines[y+i]
+		right := ansi.TruncateLeft(line, x+lipgloss.Width(fgLine), "")
+		lines[y+i] = ansi.Truncate(line, x, "") + "\x1b[0m" + fgLine + "\x1b[0m" + right
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Draws fg over the middle of bg
+func overlayCenter(bg, fg string) string {
+	x := (lipgloss.Width(bg) - lipgloss.Width(fg)) / 2
+	y := (lipgloss.Height(bg) - lipgloss.Height(fg)) / 2
+	return overlay(bg, fg, x, y)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"termfolio/data"
+)
+
+// Babel names of the interface languages. Arabic takes polyglossia and an
+// Arabic font instead, so its document is compiled with XeLaTeX.
+var latexLanguages = map[Lang]string{
+	EN: "english",
+	FR: "french",
+	ES: "spanish,es-noshorthands",
+	AR: "arabic",
+}
+
+// Escapes the characters LaTeX gives a meaning to
+var latexEscape = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"{", `\{`, "}", `\}`,
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+	"<", `\textless{}`, ">", `\textgreater{}`,
+)
+
+// Escapes the characters of a URL that would end an \href argument
+var latexURLEscape = strings.NewReplacer(`\`, `\\`, "%", `\%`, "#", `\#`, "{", `\{`, "}", `\}`)
+
+// A period of the resume data as a LaTeX range, "Oct 2025--Sept 2026"
+func latexPeriod(period string) string {
+	return strings.ReplaceAll(latexEscape.Replace(period), " - ", "--")
+}
+
+// A labelled line of a \cventry description, "\textbf{Stack:} Go, Docker"
+func latexLabelled(label, text string) string {
+	return `\textbf{` + latexEscape.Replace(label) + `} ` + text
+}
+
+// Writes the resume data as a moderncv LaTeX document (export --format tex)
+// in the theme's colors, with the labels of --lang. English, French and
+// Spanish compile with pdflatex, Arabic with xelatex and the Amiri font.
+func writeLaTeX(w io.Writer, o exportOptions) error {
+	m := o.model()
+	babel, ok := latexLanguages[o.lang]
+	if !ok {
+		return fmt.Errorf("tex: no LaTeX language for %s", o.lang)
+	}
+	colors := newPaperColors(themes[m.theme].Colors)
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	if m.rtl() {
+		line("%% Compile with xelatex")
+	} else {
+		line("%% Compile with pdflatex")
+	}
+	line(`\documentclass[11pt,a4paper,sans]{moderncv}`)
+	line(`\moderncvstyle{classic}`)
+	line(`\moderncvcolor{blue}`)
+	line(`\definecolor{color1}{HTML}{%s}`, strings.ToUpper(colors.accent.Hex()[1:]))
+	line(`\definecolor{color2}{HTML}{%s}`, strings.ToUpper(colors.muted.Hex()[1:]))
+	if m.rtl() {
+		line(`\usepackage{fontspec}`)
+		line(`\usepackage{polyglossia}`)
+		line(`\setmainlanguage{%s}`, babel)
+		line(`\setotherlanguage{english}`)
+		line(`\newfontfamily\arabicfont[Script=Arabic]{Amiri}`)
+	} else {
+		line(`\usepackage[utf8]{inputenc}`)
+		line(`\usepackage[T1]{fontenc}`)
+		line(`\usepackage[%s]{babel}`, babel)
+	}
+	line(`\usepackage[scale=0.8]{geometry}`)
+	line("")
+
+	r := data.GetResume()
+	c := r.Contact
+	given, family := c.Name, ""
+	if i := strings.LastIndex(c.Name, " "); i >= 0 {
+		given, family = c.Name[:i], c.Name[i+1:]
+	}
+	line(`\name{%s}{%s}`, latexEscape.Replace(given), latexEscape.Replace(family))
+	line(`\title{%s}`, latexEscape.Replace(c.Title))
+	line(`\address{%s}{}{}`, latexEscape.Replace(c.Location))
+	if c.Phone != "" {
+		line(`\phone[mobile]{%s}`, latexEscape.Replace(c.Phone))
+	}
+	line(`\email{%s}`, latexEscape.Replace(c.Email))
+	if c.LinkedIn != "" {
+		line(`\social[linkedin]{%s}`, latexEscape.Replace(c.LinkedIn))
+	}
+	if c.GitHub != "" {
+		line(`\social[github]{%s}`, latexEscape.Replace(c.GitHub))
+	}
+	line("")
+	line(`\begin{document}`)
+	line(`\makecvtitle`)
+
+	section := func(title string) {
+		line("")
+		line(`\section{%s}`, latexEscape.Replace(title))
+	}
+	stack := func(tags []string) string {
+		return latexLabelled(m.t("stack"), latexEscape.Replace(strings.Join(tags, ", ")))
+	}
+
+	if len(r.Experiences) > 0 {
+		section(m.t("exp_title"))
+	}
+	for _, e := range r.Experiences {
+		var description []string
+		if e.Desc != "" {
+			description = append(description, latexLabelled(m.t("mission"), latexEscape.Replace(e.Desc)))
+		}
+		if len(e.Tags) > 0 {
+			description = append(description, stack(e.Tags))
+		}
+		line(`\cventry{%s}{%s}{%s}{%s}{}{%s}`, latexPeriod(e.Period), latexEscape.Replace(e.Title), latexEscape.Replace(e.Company), latexEscape.Replace(e.Location), strings.Join(description, `\newline{}`))
+	}
+
+	if len(r.Education) > 0 {
+		section(m.t("edu_title"))
+	}
+	for _, e := range r.Education {
+		line(`\cventry{%s}{%s}{%s}{}{}{%s}`, latexPeriod(e.Period), latexEscape.Replace(e.Degree), latexEscape.Replace(e.School), latexEscape.Replace(e.Desc))
+	}
+
+	if len(r.Projects) > 0 {
+		section(m.t("proj_title"))
+	}
+	for _, p := range r.Projects {
+		var description []string
+		if p.Desc != "" {
+			description = append(description, latexEscape.Replace(p.Desc))
+		}
+		if len(p.Tags) > 0 {
+			description = append(description, stack(p.Tags))
+		}
+		name := latexEscape.Replace(p.Name)
+		if p.URL != "" {
+			name = `\href{` + latexURLEscape.Replace(p.URL) + `}{` + name + `}`
+		}
+		line(`\cventry{%s}{%s}{}{}{}{%s}`, latexPeriod(p.Date), name, strings.Join(description, `\newline{}`))
+	}
+
+	if len(r.Skills) > 0 {
+		section(m.t("skills_title"))
+		line(`\cvitem{}{%s}`, latexEscape.Replace(strings.Join(r.Skills, ", ")))
+	}
+
+	line("")
+	line(`\end{document}`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
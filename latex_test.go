@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestLatexEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"R&D", `R\&D`},
+		{"100%", `100\%`},
+		{"$5", `\$5`},
+		{"C#", `C\#`},
+		{"snake_case", `snake\_case`},
+		{"{x}", `\{x\}`},
+		{"~/bin", `\textasciitilde{}/bin`},
+		{"x^2", `x\textasciicircum{}2`},
+		{`C:\Go`, `C:\textbackslash{}Go`},
+		{`\{`, `\textbackslash{}\{`},
+		{"a < b > c", `a \textless{} b \textgreater{} c`},
+		{"Ingénieur — Paris", "Ingénieur — Paris"},
+	}
+	for _, tt := range tests {
+		if got := latexEscape.Replace(tt.in); got != tt.want {
+			t.Errorf("latexEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLatexPeriod(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Oct 2025 - Sept 2026", "Oct 2025--Sept 2026"},
+		{"2021", "2021"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := latexPeriod(tt.in); got != tt.want {
+			t.Errorf("latexPeriod(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"termfolio/data"
+)
+
+// A CSV file of a LinkedIn data export. Reading a column marks it as used,
+// so the columns left with values are the ones the import lost.
+type linkedinTable struct {
+	file    string
+	columns map[string]int
+	rows    [][]string
+	used    map[string]bool
+}
+
+// The value of column in a row, trimmed
+func (t *linkedinTable) value(row []string, column string) string {
+	t.used[column] = true
+	if i, ok := t.columns[column]; ok && i < len(row) {
+		return strings.TrimSpace(row[i])
+	}
+	return ""
+}
+
+// Problems for the columns that have values but were never read
+func (t *linkedinTable) unmapped() []string {
+	var problems []string
+	for column, i := range t.columns {
+		if t.used[column] {
+			continue
+		}
+		n := 0
+		for _, row := range t.rows {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				n++
+			}
+		}
+		if n > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %d value(s) of %q not mapped", t.file, n, column))
+		}
+	}
+	return problems
+}
+
+// Reads a CSV file of the archive; nil when the archive doesn't have it.
+// Files are matched by name wherever they are in the archive.
+func readLinkedinTable(archive *zip.Reader, name string) (*linkedinTable, error) {
+	for _, f := range archive.File {
+		if path.Base(f.Name) != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		r := csv.NewReader(rc)
+		r.FieldsPerRecord = -1
+		r.LazyQuotes = true
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		t := &linkedinTable{file: name, columns: map[string]int{}, used: map[string]bool{}}
+		if len(records) == 0 {
+			return t, nil
+		}
+		for i, column := range records[0] {
+			t.columns[strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))] = i
+		}
+		t.rows = records[1:]
+		return t, nil
+	}
+	return nil, nil
+}
+
+// Joins the start and end of a period as the resume data writes them,
+// "Oct 2025 - Present" for a position held today
+func linkedinPeriod(start, end, current string) string {
+	switch {
+	case start == "":
+		return end
+	case end == "" && current != "":
+		return start + " - " + current
+	case end == "" || end == start:
+		return start
+	}
+	return start + " - " + end
+}
+
+// The URLs of the Websites column, "[PORTFOLIO:https://…,OTHER:https://…]"
+func linkedinWebsites(websites string) []string {
+	var urls []string
+	for _, site := range strings.Split(strings.Trim(websites, "[]"), ",") {
+		if i := strings.Index(site, "http"); i >= 0 {
+			urls = append(urls, strings.TrimSpace(site[i:]))
+		}
+	}
+	return urls
+}
+
+// The files of a LinkedIn data export the resume data is read from
+var linkedinFiles = []string{"Profile.csv", "Positions.csv", "Education.csv", "Skills.csv", "Languages.csv"}
+
+// The resume data of a LinkedIn data export ("Download your data"), from
+// Profile.csv, Positions.csv, Education.csv, Skills.csv and Languages.csv.
+// Problems, such as columns the resume data has no field for or files
+// missing from the archive, are returned along with it.
+func linkedinResume(archive *zip.Reader) (data.Resume, []string, error) {
+	// LinkedIn writes its exports' dates in English, "Oct 2025"
+	r := data.Resume{Lang: "en"}
+	var problems []string
+	tables := map[string]*linkedinTable{}
+	for _, name := range linkedinFiles {
+		t, err := readLinkedinTable(archive, name)
+		if err != nil {
+			return r, nil, err
+		}
+		if t == nil {
+			problems = append(problems, name+": not in the archive")
+			t = &linkedinTable{file: name, used: map[string]bool{}}
+		}
+		tables[name] = t
+	}
+
+	profile := tables["Profile.csv"]
+	for _, row := range profile.rows {
+		r.Contact.Name = strings.TrimSpace(profile.value(row, "First Name") + " " + profile.value(row, "Last Name"))
+		r.Contact.Title = profile.value(row, "Headline")
+		r.Contact.Location = profile.value(row, "Geo Location")
+		for _, url := range linkedinWebsites(profile.value(row, "Websites")) {
+			if user, ok := strings.CutPrefix(strings.TrimPrefix(url, "https://"), "github.com/"); ok && r.Contact.GitHub == "" {
+				r.Contact.GitHub = strings.Trim(user, "/")
+			} else {
+				problems = append(problems, fmt.Sprintf("Profile.csv: website %s not mapped", url))
+			}
+		}
+	}
+	positions := tables["Positions.csv"]
+	for _, row := range positions.rows {
+		r.Experiences = append(r.Experiences, data.Experience{
+			Title:    positions.value(row, "Title"),
+			Company:  positions.value(row, "Company Name"),
+			Period:   linkedinPeriod(positions.value(row, "Started On"), positions.value(row, "Finished On"), "Present"),
+			Location: positions.value(row, "Location"),
+			Desc:     positions.value(row, "Description"),
+		})
+	}
+
+	education := tables["Education.csv"]
+	for _, row := range education.rows {
+		desc := education.value(row, "Notes")
+		if activities := education.value(row, "Activities"); activities != "" {
+			desc = strings.TrimSpace(desc + "\n" + activities)
+		}
+		r.Education = append(r.Education, data.Education{
+			Degree: education.value(row, "Degree Name"),
+			School: education.value(row, "School Name"),
+			Period: linkedinPeriod(education.value(row, "Start Date"), education.value(row, "End Date"), ""),
+			Desc:   desc,
+		})
+	}
+
+	skills := tables["Skills.csv"]
+	for _, row := range skills.rows {
+		if name := skills.value(row, "Name"); name != "" {
+			r.Skills = append(r.Skills, name)
+		}
+	}
+
+	languages := tables["Languages.csv"]
+	for _, row := range languages.rows {
+		if name := languages.value(row, "Name"); name != "" {
+			if r.Languages == nil {
+				r.Languages = map[string]string{}
+			}
+			r.Languages[name] = languages.value(row, "Proficiency")
+		}
+	}
+
+	for _, name := range linkedinFiles {
+		unmapped := tables[name].unmapped()
+		slices.Sort(unmapped)
+		problems = append(problems, unmapped...)
+	}
+	return r, problems, nil
+}
+
+// Carries over from base what a LinkedIn export doesn't have: the projects
+// and interests, along with the email, phone, profile name, gender and
+// GitHub profile. Only a base of the same person is used; otherwise what it
+// has is reported as dropped. Contact details still missing are reported too.
+func keepFromBase(r *data.Resume, base data.Resume, from string) []string {
+	var problems []string
+	same := base.Contact.Name != "" && strings.EqualFold(base.Contact.Name, r.Contact.Name)
+	keep := func(what string, n int) {
+		switch {
+		case n == 0:
+		case same:
+			problems = append(problems, fmt.Sprintf("%s: kept %d from %s", what, n, from))
+		default:
+			problems = append(problems, fmt.Sprintf("%s: dropped %d from %s, whose name isn't %q", what, n, from, r.Contact.Name))
+		}
+	}
+	keep("Projects", len(base.Projects))
+	keep("Interests", len(base.Interests))
+	if same {
+		r.Projects = base.Projects
+		r.Interests = base.Interests
+	}
+
+	var missing []string
+	for _, field := range []struct {
+		name     string
+		value    *string
+		fromBase string
+	}{
+		{"Email", &r.Contact.Email, base.Contact.Email},
+		{"Phone", &r.Contact.Phone, base.Contact.Phone},
+		{"LinkedIn", &r.Contact.LinkedIn, base.Contact.LinkedIn},
+		{"GitHub", &r.Contact.GitHub, base.Contact.GitHub},
+		{"Gender", &r.Contact.Gender, base.Contact.Gender},
+	} {
+		if *field.value == "" && same {
+			*field.value = field.fromBase
+		}
+		if *field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "Contact: no "+strings.Join(missing, ", ")+" in these files; fill them in by hand")
+	}
+	return problems
+}
+
+// termfolio import linkedin <archive.zip> [--output <file>]
+func importCommand(args []string) error {
+	if len(args) == 0 || args[0] != "linkedin" {
+		return fmt.Errorf("usage: termfolio import linkedin <archive.zip> [--output resume.toml]")
+	}
+	fs := flag.NewFlagSet("import linkedin", flag.ContinueOnError)
+	output := fs.String("output", "", "Resume file to write, e.g. ~/.config/termfolio/resume.toml; defaults to standard output")
+	fs.StringVar(output, "o", "", "Shorthand for --output")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	// The archive may come before the flags too
+	archivePath := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[min(1, fs.NArg()):]); err != nil {
+		return err
+	}
+	if archivePath == "" || fs.NArg() > 0 {
+		return fmt.Errorf("usage: termfolio import linkedin <archive.zip> [--output resume.toml]")
+	}
+
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	r, problems, err := linkedinResume(&archive.Reader)
+	if err != nil {
+		return err
+	}
+
+	// The resume being replaced keeps what LinkedIn doesn't export: the
+	// output file when there is one, else the resume in use
+	base, from := data.GetResume(), "the current resume"
+	if *output != "" {
+		switch b, err := data.ReadFile(*output); {
+		case err == nil:
+			base, from = b, *output
+		case !errors.Is(err, os.ErrNotExist):
+			return err
+		}
+	}
+	problems = append(problems, keepFromBase(&r, base, from)...)
+	for _, problem := range problems {
+		log.Printf("linkedin: %s", problem)
+	}
+
+	if *output == "" {
+		return r.Encode(os.Stdout)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := r.Encode(f); err != nil {
+		f.Close()
+		os.Remove(*output)
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"slices"
+	"testing"
+
+	"termfolio/data"
+)
+
+// A LinkedIn archive holding the given files, as they sit in the export
+func linkedinArchive(t *testing.T, files map[string]string) *zip.Reader {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range files {
+		f, err := w.Create("Basic_LinkedInDataExport/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestLinkedinResume(t *testing.T) {
+	archive := linkedinArchive(t, map[string]string{
+		"Profile.csv": "\ufeffFirst Name,Last Name,Headline,Summary,Geo Location,Websites\n" +
+			`Ada,Lovelace,Engineer,"Writes programs, mostly",London,"[PORTFOLIO:https://ada.dev,OTHER:https://github.com/ada/]"` + "\n",
+		"Positions.csv": "Company Name,Title,Description,Location,Started On,Finished On\n" +
+			`Analytical Engines,Programmer,"Notes on the engine, with ""Note G""",London,Jun 1842,` + "\n" +
+			"Babbage & Co,Translator,,,Jan 1842,Mar 1842\n",
+		"Skills.csv":    "Name\nMathematics\n\nPoetry\n",
+		"Languages.csv": "Name,Proficiency\nFrench,Full professional proficiency\n",
+	})
+	r, problems, err := linkedinResume(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := data.Resume{
+		Lang: "en",
+		Contact: data.Contact{
+			Name:     "Ada Lovelace",
+			Title:    "Engineer",
+			Location: "London",
+			GitHub:   "ada",
+		},
+		Experiences: []data.Experience{
+			{Title: "Programmer", Company: "Analytical Engines", Period: "Jun 1842 - Present", Location: "London", Desc: `Notes on the engine, with "Note G"`},
+			{Title: "Translator", Company: "Babbage & Co", Period: "Jan 1842 - Mar 1842"},
+		},
+		Skills:    []string{"Mathematics", "Poetry"},
+		Languages: map[string]string{"French": "Full professional proficiency"},
+	}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("linkedinResume() =\n%+v\nwant\n%+v", r, want)
+	}
+	for _, problem := range []string{
+		"Education.csv: not in the archive",
+		"Profile.csv: website https://ada.dev not mapped",
+		`Profile.csv: 1 value(s) of "Summary" not mapped`,
+	} {
+		if !slices.Contains(problems, problem) {
+			t.Errorf("linkedinResume() problems = %q, want %q among them", problems, problem)
+		}
+	}
+}
+
+func TestLinkedinPeriod(t *testing.T) {
+	tests := []struct {
+		start, end, current, want string
+	}{
+		{"Oct 2025", "Sept 2026", "Present", "Oct 2025 - Sept 2026"},
+		{"Oct 2025", "", "Present", "Oct 2025 - Present"},
+		{"2019", "", "", "2019"},
+		{"2019", "2019", "", "2019"},
+		{"", "2021", "", "2021"},
+	}
+	for _, tt := range tests {
+		if got := linkedinPeriod(tt.start, tt.end, tt.current); got != tt.want {
+			t.Errorf("linkedinPeriod(%q, %q, %q) = %q, want %q", tt.start, tt.end, tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Locale variables in order of precedence, as gettext resolves them
+var localeVars = []string{"LANGUAGE", "LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Picks the UI language from locale variables given as "KEY=value"
+// entries (os.Environ or an SSH session's environment). Every candidate is
+// tried in order of precedence, and EN is used when none is supported.
+func langFromEnv(environ []string) Lang {
+	env := map[string]string{}
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+
+	for _, key := range localeVars {
+		// LANGUAGE is a colon-separated list of preferences
+		for _, locale := range strings.Split(env[key], ":") {
+			if lang, ok := parseLocale(locale); ok {
+				return lang
+			}
+		}
+	}
+	return EN
+}
+
+// Maps a POSIX locale such as "fr_CA.UTF-8@euro" to a supported language,
+// falling back from the territory variant to the bare language code
+func parseLocale(locale string) (Lang, bool) {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.ToLower(strings.ReplaceAll(locale, "-", "_"))
+
+	for _, candidate := range []string{locale, strings.SplitN(locale, "_", 2)[0]} {
+		if langIndex(Lang(candidate)) >= 0 {
+			return Lang(candidate), true
+		}
+	}
+	return "", false
+}
+
+// Resolves the --lang flag: an explicit language wins, otherwise the local
+// environment decides
+func startLang(flagValue string) (Lang, error) {
+	if flagValue == "" {
+		return langFromEnv(os.Environ()), nil
+	}
+	if lang, ok := parseLocale(flagValue); ok {
+		return lang, nil
+	}
+	return "", fmt.Errorf("unsupported language %q (available: %s)", flagValue, strings.Join(langCodes(), ", "))
+}
@@ -9,6 +9,9 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,34 +19,10 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
-	"github.com/charmbracelet/wish/activeterm"
 	bm "github.com/charmbracelet/wish/bubbletea"
 	lm "github.com/charmbracelet/wish/logging"
-)
-
-// Colors
-var (
-	primaryColor   = lipgloss.Color("#0174DF") // Blue accent
-	secondaryColor = lipgloss.Color("#08298A") // Dark blue
-	textColor      = lipgloss.Color("#FFFFFF")
-	mutedColor     = lipgloss.Color("#666666")
-)
-
-// Styles
-var (
-	titleStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
-
-	contentStyle = lipgloss.NewStyle().
-			Foreground(textColor)
-
-	mutedStyle = lipgloss.NewStyle().
-			Foreground(mutedColor)
-
-	footerStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			MarginTop(1)
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
 )
 
 // Minimum dimensions
@@ -96,8 +75,12 @@ const (
 // Tick message for animation
 type tickMsg time.Time
 
+// Delivers a message after a while, as tea.Tick; record replaces it to run
+// the model on the clock of its script
+var after = tea.Tick
+
 func doTick() tea.Cmd {
-	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+	return after(tickInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -108,207 +91,10 @@ type Lang string
 const (
 	EN Lang = "en"
 	FR Lang = "fr"
+	ES Lang = "es"
+	AR Lang = "ar"
 )
 
-// Translations
-var translations = map[Lang]map[string]string{
-	EN: {
-		"subtitle":       "Computer Science & Networks Engineer",
-		"about_title":    "About Me",
-		"bio_title":      "Biography",
-		"about_1":        "Engineer with nearly 3 years of hands-on",
-		"about_2":        "experience, passionate about building robust",
-		"about_3":        "systems — from scalable APIs to cybersecurity.",
-		"about_4":        "Driven by curiosity and impact, with a focus",
-		"about_5":        "on system security and software quality.",
-		"about_6":        "Also into F1, gaming, and astrophysics.",
-		"exp_title":      "Experience",
-		"exp_scroll":     "(↑↓ to scroll)",
-		"mission":        "Mission:",
-		"stack":          "Stack:",
-		"challenge":      "Challenge:",
-		"feedback":       "Feedback:",
-		"gw_role":        "Paris | Oct 2025 - Sept 2026",
-		"gw_mission_1":   "Developing and maintaining GCap, Gatewatcher's NDR",
-		"gw_mission_2":   "(Network Detection & Response) solution, while working",
-		"gw_mission_3":   "on open-source security projects in parallel.",
-		"gw_mission_4":   "Managing cloud infrastructure (Proxmox, Nutanix, Azure,",
-		"gw_mission_5":   "AWS) and building internal tooling for the team.",
-		"gw_challenge":   "Ramped up on cybersecurity from the ground up.",
-		"gw_feedback_1":  "Thriving in a fast-paced environment with a strong",
-		"gw_feedback_2":  "engineering culture and thoughtful use of AI.",
-		"gw_title":       "Cybersecurity Engineer",
-		"etifak_role":    "Remote | Sept 2024 - Sept 2025",
-		"etifak_title":   "Backend Developer",
-		"etifak_mission_1": "Led backend development for B2B marketplace",
-		"etifak_mission_2": "at an early-stage startup. Built REST APIs, unit tests,",
-		"etifak_mission_3": "and managed AWS deployment.",
-		"etifak_challenge": "Self-taught many concepts as a junior dev.",
-		"etifak_feedback":  "Great team communication made workflow smooth!",
-		"suez_role":      "Paris | Jun - Aug 2024",
-		"suez_title":     "Data Engineer",
-		"suez_mission_1": "Internship: Built intranet web app for data quality & cleaning.",
-		"suez_mission_2": "Implemented deduplication using Levenshtein distance.",
-		"suez_feedback":  "First internship. Great Scrum team dynamics!",
-		"proj_title":     "Projects",
-		"sls_desc_1":     "Community-driven platform providing free car setups",
-		"sls_desc_2":     "for Assetto Corsa Competizione. Full-stack project",
-		"sls_desc_3":     "with REST API, database management, and modern frontend.",
-		"sls_status":     "Status: Live & actively maintained",
-		"mealpass_desc_1": "Mobile app managing food distributions for students",
-		"mealpass_desc_2": "in need. QR-code based check-in system with real-time",
-		"mealpass_desc_3": "tracking and admin dashboard.",
-		"mealpass_status": "Status: Completed — volunteer project for an association",
-		"termfolio_desc_1": "Terminal-based portfolio built with Go and Bubble Tea.",
-		"termfolio_desc_2": "Interactive TUI with bilingual support, SSH access,",
-		"termfolio_desc_3": "and a custom-rendered UI with box-drawing characters.",
-		"termfolio_status":    "Status: Live & actively maintained — ",
-		"termfolio_highlight": "you're looking at it",
-		"vectorart_desc_1": "Illustrator-like vector drawing application with basic",
-		"vectorart_desc_2": "shape tools, layers, and export features.",
-		"vectorart_status": "Status: Completed — 1st year project at ENSISA",
-		"discordclone_desc_1": "Real-time messaging platform inspired by Discord",
-		"discordclone_desc_2": "with channels, user auth, and live chat.",
-		"discordclone_status": "Status: Completed — 1st year project at ENSISA",
-		"schoolmgmt_desc_1": "Management platform for students, courses, and grades",
-		"schoolmgmt_desc_2": "with admin dashboard and role-based access.",
-		"schoolmgmt_status": "Status: Completed — 1st year project at ENSISA",
-		"skills_title":   "Skills",
-		"contact_title":  "Contact",
-		"contact_reach":  "Feel free to reach out!",
-		"contact_open":   "Open to opportunities in:",
-		"contact_backend": "Backend Development",
-		"contact_cyber":  "Cybersecurity",
-		"contact_devops": "DevOps",
-		"edu_title":      "Education",
-		"ensisa_degree":  "Engineering Degree: Computer Science & Networks",
-		"ensisa_school":  "ENSISA - National School of Engineers of South Alsace",
-		"ensisa_period":  "Sept 2023 - Sept 2026",
-		"ensisa_loc":     "Mulhouse, France",
-		"ensisa_desc_1":  "Software development, databases, DevOps,",
-		"ensisa_desc_2":  "networks & security, AI and deep learning.",
-		"ensisa_desc_3":  "Hands-on projects in full-stack, cloud, and cybersecurity.",
-		"cpge_degree":    "Preparatory Classes MPSI",
-		"cpge_school":    "Carnot Prépas",
-		"cpge_period":    "Sept 2021 - Jul 2023",
-		"cpge_loc":       "Meknes, Morocco",
-		"cpge_desc_1":    "Intensive program in Mathematics, Physics and",
-		"cpge_desc_2":    "Engineering Sciences. Developed strong analytical",
-		"cpge_desc_3":    "thinking and problem-solving skills.",
-		"bac_degree":     "Baccalaureate - Science Math A",
-		"bac_school":     "Lycée Ajana",
-		"bac_period":     "2021",
-		"bac_loc":        "Meknes, Morocco",
-		"bac_desc_1":     "French Option. Strong foundation in mathematics",
-		"bac_desc_2":     "and scientific reasoning.",
-		"footer":         "q: Quit • ←→: Navigate • Tab: Language",
-		"email":          "Email",
-		"location":       "Location",
-		"linkedin":       "LinkedIn",
-		"github":         "GitHub",
-	},
-	FR: {
-		"subtitle":       "Ingénieur en informatique et réseaux",
-		"about_title":    "À propos",
-		"bio_title":      "Biographie",
-		"about_1":        "Ingénieur avec près de 3 ans d'expérience,",
-		"about_2":        "passionné par la conception de systèmes fiables",
-		"about_3":        "— des APIs scalables à la cybersécurité.",
-		"about_4":        "Animé par la curiosité et l'impact, avec un",
-		"about_5":        "intérêt pour la sécurité et la qualité logicielle.",
-		"about_6":        "Aussi passionné de F1, gaming et astrophysique.",
-		"exp_title":      "Expériences",
-		"exp_scroll":     "(↑↓ pour défiler)",
-		"mission":        "Mission:",
-		"stack":          "Stack:",
-		"challenge":      "Défi:",
-		"feedback":       "Avis:",
-		"gw_role":        "Paris | Oct 2025 - Sept 2026",
-		"gw_mission_1":   "Développement et maintien de GCap, la solution NDR",
-		"gw_mission_2":   "(Network Detection & Response) de Gatewatcher, en",
-		"gw_mission_3":   "parallèle de projets open-source de sécurité.",
-		"gw_mission_4":   "Gestion d'infrastructure cloud (Proxmox, Nutanix,",
-		"gw_mission_5":   "Azure, AWS) et création d'outillage interne.",
-		"gw_challenge":   "Montée en compétence cybersécurité en partant de zéro.",
-		"gw_feedback_1":  "Environnement stimulant avec une forte culture",
-		"gw_feedback_2":  "d'ingénierie et une utilisation réfléchie de l'IA.",
-		"gw_title":       "Ingénieur Cybersécurité",
-		"etifak_role":    "Remote | Sept 2024 - Sept 2025",
-		"etifak_title":   "Développeur Backend",
-		"etifak_mission_1": "Développement backend pour marketplace B2B",
-		"etifak_mission_2": "dans une startup. APIs REST, tests unitaires,",
-		"etifak_mission_3": "et déploiement AWS.",
-		"etifak_challenge": "Auto-apprentissage en tant que dev junior.",
-		"etifak_feedback":  "Excellente communication d'équipe!",
-		"suez_role":      "Paris | Juin - Août 2024",
-		"suez_title":     "Data Engineer",
-		"suez_mission_1": "Stage : Application intranet pour qualité des données.",
-		"suez_mission_2": "Déduplication avec distance de Levenshtein.",
-		"suez_feedback":  "Premier stage. Super dynamique Scrum!",
-		"proj_title":     "Projets",
-		"sls_desc_1":     "Plateforme communautaire proposant des setups gratuits",
-		"sls_desc_2":     "pour Assetto Corsa Competizione. Projet full-stack avec",
-		"sls_desc_3":     "API REST, gestion de base de données et frontend moderne.",
-		"sls_status":     "Statut : En ligne & maintenu activement",
-		"mealpass_desc_1": "Application mobile de gestion de distributions",
-		"mealpass_desc_2": "alimentaires pour étudiants précaires. Système de",
-		"mealpass_desc_3": "check-in par QR-code avec suivi en temps réel.",
-		"mealpass_status": "Statut : Terminé — projet bénévole pour une association",
-		"termfolio_desc_1": "Portfolio terminal construit avec Go et Bubble Tea.",
-		"termfolio_desc_2": "TUI interactive avec support bilingue, accès SSH,",
-		"termfolio_desc_3": "et rendu personnalisé avec des caractères box-drawing.",
-		"termfolio_status":    "Statut : En ligne & maintenu — ",
-		"termfolio_highlight": "vous le consultez en ce moment",
-		"vectorart_desc_1": "Application de dessin vectoriel type Illustrator avec",
-		"vectorart_desc_2": "outils de formes, calques et export.",
-		"vectorart_status": "Statut : Terminé — projet 1ère année ENSISA",
-		"discordclone_desc_1": "Plateforme de messagerie en temps réel inspirée de",
-		"discordclone_desc_2": "Discord avec salons, authentification et chat en direct.",
-		"discordclone_status": "Statut : Terminé — projet 1ère année ENSISA",
-		"schoolmgmt_desc_1": "Plateforme de gestion d'étudiants, cours et notes",
-		"schoolmgmt_desc_2": "avec tableau de bord admin et accès par rôles.",
-		"schoolmgmt_status": "Statut : Terminé — projet 1ère année ENSISA",
-		"skills_title":   "Compétences",
-		"contact_title":  "Contact",
-		"contact_reach":  "N'hésitez pas à me contacter!",
-		"contact_open":   "Ouvert aux opportunités en:",
-		"contact_backend": "Développement Backend",
-		"contact_cyber":  "Cybersécurité",
-		"contact_devops": "DevOps",
-		"edu_title":      "Formation",
-		"ensisa_degree":  "Diplôme d'ingénieur : Informatique et Réseaux",
-		"ensisa_school":  "ENSISA - École Nationale Supérieure d'Ingénieurs Sud-Alsace",
-		"ensisa_period":  "Sept 2023 - Sept 2026",
-		"ensisa_loc":     "Mulhouse, France",
-		"ensisa_desc_1":  "Développement logiciel, bases de données, DevOps,",
-		"ensisa_desc_2":  "réseaux et sécurité, IA et deep learning.",
-		"ensisa_desc_3":  "Projets pratiques en full-stack, cloud et cybersécurité.",
-		"cpge_degree":    "Classes Préparatoires MPSI",
-		"cpge_school":    "Carnot Prépas",
-		"cpge_period":    "Sept 2021 - Juil 2023",
-		"cpge_loc":       "Meknès, Maroc",
-		"cpge_desc_1":    "Programme intensif en Mathématiques, Physique et",
-		"cpge_desc_2":    "Sciences de l'Ingénieur. Développement de la rigueur",
-		"cpge_desc_3":    "analytique et des capacités de résolution de problèmes.",
-		"bac_degree":     "Baccalauréat - Option Science Math A",
-		"bac_school":     "Lycée Ajana",
-		"bac_period":     "2021",
-		"bac_loc":        "Meknès, Maroc",
-		"bac_desc_1":     "Option Française. Solide formation en mathématiques",
-		"bac_desc_2":     "et raisonnement scientifique.",
-		"footer":         "q: Quitter • ←→: Naviguer • Tab: Langue",
-		"email":          "Email",
-		"location":       "Lieu",
-		"linkedin":       "LinkedIn",
-		"github":         "GitHub",
-	},
-}
-
-// ASCII name
-var name = `╔╦╗╔═╗╦ ╦╔═╗╔╦╗╔═╗╔╦╗  ╔═╗╔═╗╔═╗╦ ╦╔═╗
-║║║║ ║╠═╣╠═╣║║║║╣  ║║  ║ ╦╠═╣║  ╠═╣╠═╣
-╩ ╩╚═╝╩ ╩╩ ╩╩ ╩╚═╝═╩╝  ╚═╝╩ ╩╚═╝╩ ╩╩ ╩`
-
 type model struct {
 	width  int
 	height int
@@ -322,27 +108,73 @@ type model struct {
 	animPos   int
 	animTicks int
 	cursorOn  bool
+
+	// Navigation history
+	history []location
+	future  []location
+
+	// First tab shown when the navbar is scrolled
+	navOffset int
+
+	// Language picker popup
+	picker       bool
+	pickerCursor int
+
+	// QR code popup and the code it shows (index in qrTargets)
+	qr       bool
+	qrCursor int
+
+	// Records translation keys looked up while rendering (catalogReport)
+	usedKeys map[string]bool
+
+	// Active theme (index in themes) and the styles built from it for the
+	// renderer of this model's terminal
+	theme    int
+	styles   styles
+	renderer *lipgloss.Renderer
+
+	// Draw with ASCII characters only (see asciiTerminal)
+	ascii bool
+
+	// Terminal opens OSC 8 hyperlinks; otherwise links get footnotes
+	hyperlinks bool
+
+	// Frames are plain text, without any escape sequence (print --no-color)
+	plain bool
+
+	// Link focused with n/N, 1-based in focusLinks; 0 when none
+	focused int
+
+	// Message shown in place of the footer, and the id of the latest one
+	toast   string
+	toastID int
 }
 
+// A visited position: tab, entry within the tab and scroll offset
+type location struct {
+	tab    int
+	entry  int
+	scroll int
+}
+
+// Maximum number of locations kept in the back history
+const maxHistory = 50
+
 // Tracks max scroll from the last render frame
 var lastMaxScroll int
 
-func initialModel() model {
-	return model{
-		width:    80,
-		height:   24,
-		cursor:   0,
-		lang:     EN,
-		cursorOn: true,
-	}
-}
-
-// Helper to get translation
-func (m model) t(key string) string {
-	if val, ok := translations[m.lang][key]; ok {
-		return val
+func initialModel(lang Lang) model {
+	m := model{
+		width:      80,
+		height:     24,
+		cursor:     0,
+		lang:       lang,
+		cursorOn:   true,
+		renderer:   lipgloss.DefaultRenderer(),
+		hyperlinks: true,
 	}
-	return key
+	m.setTheme(0)
+	return m
 }
 
 func (m model) Init() tea.Cmd {
@@ -362,19 +194,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		case PortfolioScreen:
+			if m.picker {
+				if msg.String() == "ctrl+c" {
+					return m, tea.Quit
+				}
+				m.updatePicker(msg.String())
+				break
+			}
+			if m.qr {
+				if msg.String() == "ctrl+c" {
+					return m, tea.Quit
+				}
+				m.updateQR(msg.String())
+				break
+			}
+
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
-			case "left", "h":
-				if m.cursor > 0 {
-					m.cursor--
-					m.scroll = 0
+			case "left", "h", "right", "l":
+				// Tabs run right to left in RTL layouts, so arrows follow the screen
+				step := 1
+				if msg.String() == "left" || msg.String() == "h" {
+					step = -1
+				}
+				if m.rtl() {
+					step = -step
 				}
-			case "right", "l":
-				if m.cursor < 3 {
-					m.cursor++
-					m.scroll = 0
+				if tab := m.cursor + step; tab >= 0 && tab < len(m.tabTitles()) {
+					m.navigate(tab)
 				}
+			case "[", "alt+left", "backspace":
+				m.back()
+			case "]", "alt+right":
+				m.forward()
 			case "up", "k":
 				if m.scroll > 0 {
 					m.scroll--
@@ -384,11 +237,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.scroll++
 				}
 			case "tab":
-				if m.lang == EN {
-					m.lang = FR
-				} else {
-					m.lang = EN
-				}
+				m.cycleLang(1)
+			case "shift+tab":
+				m.cycleLang(-1)
+			case "L":
+				m.openPicker()
+			case "t":
+				m.cycleTheme(1)
+			case "T":
+				m.cycleTheme(-1)
+			case "n":
+				m.focusLink(1)
+			case "N":
+				m.focusLink(-1)
+			case "esc":
+				m.focused = 0
+			case "y":
+				return m, m.yank()
+			case "c":
+				m.openQR()
 			}
 		}
 
@@ -396,6 +263,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+
 	case tickMsg:
 		if m.screen == WelcomeScreen {
 			advanceAnimation(&m)
@@ -403,6 +275,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	m.scrollNavbar()
 	return m, nil
 }
 
@@ -509,11 +382,212 @@ func advanceAnimation(m *model) {
 	}
 }
 
-func (m model) View() string {
-	if m.screen == WelcomeScreen {
-		return m.welcomeView()
+// A titled block of the right panel: one job, degree, project or skill group
+type entry struct {
+	title string
+	lines []string
+}
+
+// Right panel content of a tab
+type tabContent struct {
+	header  string
+	entries []entry
+	compact bool // no blank line between entries
+}
+
+// Navbar labels, one per tab
+func (m model) tabTitles() []string {
+	return []string{m.t("exp_title"), m.t("edu_title"), m.t("proj_title"), m.t("skills_title")}
+}
+
+// Right panel content of a tab, rendered from its resume section
+func (m model) tabEntries(tab int) tabContent {
+	sections := m.resume()
+	if tab < 0 || tab >= len(sections) {
+		return tabContent{}
+	}
+	s := sections[tab]
+	c := tabContent{
+		header:  m.styles.title.Render(s.title) + " " + m.styles.muted.Render(m.t("exp_scroll")),
+		compact: s.compact,
+	}
+
+	// Skill lists line up after the longest group name
+	nameWidth := 0
+	for _, e := range s.entries {
+		nameWidth = max(nameWidth, lipgloss.Width(e.title))
+	}
+
+	for _, e := range s.entries {
+		if s.compact {
+			pad := repeatString(" ", nameWidth-lipgloss.Width(e.title)+2)
+			c.entries = append(c.entries, entry{e.name, []string{m.styles.title.Render(e.title) + pad + m.styles.content.Render(e.blocks[0].text())}})
+			continue
+		}
+
+		title := e.title
+		if e.org != "" {
+			title = e.org + " ✕ " + title
+		}
+		if e.url != "" {
+			title = link(e.url, title)
+		}
+		lines := []string{m.entryTitle(title)}
+		for _, b := range e.blocks {
+			lines = append(lines, m.blockLines(b)...)
+		}
+		c.entries = append(c.entries, entry{e.name, lines})
+	}
+	return c
+}
+
+// Styled lines of a block: labels and meta lines muted, text in the content
+// style
+func (m model) blockLines(b resumeBlock) []string {
+	switch b.kind {
+	case blockMeta:
+		var lines []string
+		for _, line := range b.lines {
+			lines = append(lines, m.styles.muted.Render(line))
+		}
+		if b.highlight != "" {
+			lines[len(lines)-1] += m.styles.highlight.Render(" " + b.highlight + " ")
+		}
+		return lines
+	case blockTags:
+		return []string{m.styles.muted.Render(b.label) + " " + m.styles.content.Render(b.text())}
+	}
+
+	var lines []string
+	for _, line := range b.lines {
+		lines = append(lines, m.styles.content.Render(line))
+	}
+	if b.label != "" {
+		lines[0] = m.styles.muted.Render(b.label) + " " + lines[0]
+	}
+	return lines
+}
+
+// Entry heading between rules: ━━━ Title ━━━
+func (m model) entryTitle(title string) string {
+	rule := repeatString(m.styles.box.rule, 3)
+	return m.styles.title.Render(rule + " " + title + " " + rule)
+}
+
+// Renders a tab's content at the panel width, returning its lines and the
+// line on which each entry starts
+func renderPanel(c tabContent, width int) ([]string, []int) {
+	panel := lipgloss.NewStyle().
+		PaddingLeft(1).
+		Width(width)
+
+	lines := splitLines(panel.Render(c.header))
+	var starts []int
+	for i, e := range c.entries {
+		if i == 0 || !c.compact {
+			lines = append(lines, "")
+		}
+		starts = append(starts, len(lines))
+		lines = append(lines, splitLines(panel.Render(lipgloss.JoinVertical(lipgloss.Left, e.lines...)))...)
+	}
+	return lines, starts
+}
+
+// Index of the last entry starting at or above the scroll offset
+func entryAt(starts []int, scroll int) int {
+	entry := 0
+	for i, start := range starts {
+		if start <= scroll {
+			entry = i
+		}
+	}
+	return entry
+}
+
+// Breadcrumb of a location, e.g. "Experience › Etifak"
+func (m model) breadcrumb(c tabContent, entry int) string {
+	crumb := m.tabTitles()[m.cursor]
+	if entry < len(c.entries) {
+		crumb += " › " + c.entries[entry].title
+	}
+	return crumb
+}
+
+// Current location, with the entry resolved from the scroll offset
+func (m model) location() location {
+	_, starts := renderPanel(m.tabContent(m.cursor), m.layout().rightPanelWidth)
+	return location{tab: m.cursor, entry: entryAt(starts, m.scroll), scroll: m.scroll}
+}
+
+// Switches tab, recording the current location in the history
+func (m *model) navigate(tab int) {
+	m.history = append(m.history, m.location())
+	if len(m.history) > maxHistory {
+		m.history = m.history[1:]
+	}
+	m.future = nil
+	m.cursor = tab
+	m.scroll = 0
+	m.focused = 0
+}
+
+func (m *model) back() {
+	if len(m.history) == 0 {
+		return
+	}
+	m.future = append(m.future, m.location())
+	loc := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.restore(loc)
+}
+
+func (m *model) forward() {
+	if len(m.future) == 0 {
+		return
+	}
+	m.history = append(m.history, m.location())
+	loc := m.future[len(m.future)-1]
+	m.future = m.future[:len(m.future)-1]
+	m.restore(loc)
+}
+
+// Jumps to a saved location. When a resize or language switch moved the
+// entry away from the saved scroll offset, the entry's first line wins.
+func (m *model) restore(loc location) {
+	l := m.layout()
+	lines, starts := renderPanel(m.tabContent(loc.tab), l.rightPanelWidth)
+
+	m.cursor = loc.tab
+	m.scroll = loc.scroll
+	m.focused = 0
+	if loc.entry < len(starts) && entryAt(starts, m.scroll) != loc.entry {
+		m.scroll = starts[loc.entry]
 	}
 
+	maxScroll := len(lines) - l.contentHeight
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.scroll > maxScroll {
+		m.scroll = maxScroll
+	}
+}
+
+// Clickable link (OSC 8 hyperlink)
+func link(url, text string) string {
+	return fmt.Sprintf("\x1b]8;;%s\x07%s\x1b]8;;\x07", url, text)
+}
+
+// Frame layout derived from the window size
+type layout struct {
+	width           int
+	height          int
+	leftPanelWidth  int
+	rightPanelWidth int
+	contentHeight   int
+}
+
+func (m model) layout() layout {
 	// Calculate dimensions with minimums
 	width := m.width
 	height := m.height
@@ -528,29 +602,151 @@ func (m model) View() string {
 	// Frame dimensions (with margins)
 	innerWidth := width - 4
 
-	// Menu items horizontal
-	menuItems := []string{"Experience", m.t("edu_title"), "Projects", "Skills"}
+	// Panel widths for vertical split (30% left, 70% right)
+	leftPanelWidth := innerWidth*30/100 + 1
+
+	return layout{
+		width:           width,
+		height:          height,
+		leftPanelWidth:  leftPanelWidth,
+		rightPanelWidth: innerWidth - leftPanelWidth - 1, // -1 for the middle │
+		contentHeight:   height - 7,                      // Leave space for top (3 lines) + bottom (3 lines) + margin
+	}
+}
+
+// Width available to navbar items: the top border keeps ┬─ on the left,
+// ┤├ around the bar and one dash on the right, and the bar pads itself by 1
+func (l layout) navbarWidth() int {
+	return l.rightPanelWidth - 6
+}
+
+// Shortest abbreviated navbar title, ellipsis included
+const minTabTitle = 4
+
+// Narrowest breadcrumb worth showing; below that it is hidden
+const minCrumb = 8
+
+// Width of navbar items, each padded with a space on both sides
+func itemsWidth(titles []string) int {
+	w := 0
+	for _, t := range titles {
+		w += lipgloss.Width(t) + 2
+	}
+	return w
+}
+
+// Tab titles fitted to the navbar width. Inactive titles are abbreviated
+// first; the active one is only cut when it cannot fit on its own.
+func (m model) fitTabTitles(width int) []string {
+	titles := m.tabTitles()
+	longest := 0
+	for _, t := range titles {
+		longest = max(longest, lipgloss.Width(t))
+	}
+
+	fitted := make([]string, len(titles))
+	for size := longest; size >= minTabTitle; size-- {
+		for i, t := range titles {
+			if i == m.cursor {
+				fitted[i] = t
+			} else {
+				fitted[i] = ansi.Truncate(t, size, "…")
+			}
+		}
+		if itemsWidth(fitted) <= width {
+			break
+		}
+	}
+
+	// Room for the active tab and both ‹ › indicators
+	fitted[m.cursor] = ansi.Truncate(fitted[m.cursor], width-4, "…")
+	return fitted
+}
+
+// End (exclusive) of the tabs shown from offset, reserving a column for
+// each ‹ › indicator that is needed
+func tabsEnd(titles []string, offset, width int) int {
+	if offset > 0 {
+		width-- // ‹
+	}
+	if itemsWidth(titles[offset:]) <= width {
+		return len(titles)
+	}
+	width-- // ›
+
+	end := offset
+	for end < len(titles) && itemsWidth(titles[offset:end+1]) <= width {
+		end++
+	}
+	return max(end, offset+1)
+}
+
+// Scrolls the navbar just enough to keep the active tab visible, and back
+// towards the first tab when a wider window leaves room for it
+func (m *model) scrollNavbar() {
+	width := m.layout().navbarWidth()
+	titles := m.fitTabTitles(width)
+
+	if m.navOffset > m.cursor {
+		m.navOffset = m.cursor
+	}
+	for tabsEnd(titles, m.navOffset, width) <= m.cursor {
+		m.navOffset++
+	}
+	for m.navOffset > 0 && tabsEnd(titles, m.navOffset-1, width) == len(titles) {
+		m.navOffset--
+	}
+}
+
+func (m model) View() string {
+	if m.screen == WelcomeScreen {
+		return m.toASCII(m.welcomeView())
+	}
+
+	l := m.layout()
+	b := m.styles.box
+	width := l.width
+	leftPanelWidth := l.leftPanelWidth
+	rightPanelWidth := l.rightPanelWidth
+
+	// Menu items horizontal, scrolled and abbreviated to fit. Right-to-left
+	// layouts list the tabs from the right edge.
+	navWidth := l.navbarWidth()
+	menuItems := m.fitTabTitles(navWidth)
+	navEnd := tabsEnd(menuItems, m.navOffset, navWidth)
+	moreBefore, moreAfter := "‹", "›"
+	if m.rtl() {
+		moreBefore, moreAfter = moreAfter, moreBefore
+	}
 	var menuDisplay []string
-	for i, item := range menuItems {
+	if m.navOffset > 0 {
+		menuDisplay = append(menuDisplay, m.styles.muted.Render(moreBefore))
+	}
+	for i := m.navOffset; i < navEnd; i++ {
+		item := " " + visualOrder(menuItems[i]) + " "
 		if i == m.cursor {
-			menuDisplay = append(menuDisplay, titleStyle.Render(" "+item+" "))
+			menuDisplay = append(menuDisplay, m.styles.title.Render(item))
 		} else {
-			menuDisplay = append(menuDisplay, contentStyle.Render(" "+item+" "))
+			menuDisplay = append(menuDisplay, m.styles.content.Render(item))
 		}
 	}
+	if navEnd < len(menuItems) {
+		menuDisplay = append(menuDisplay, m.styles.muted.Render(moreAfter))
+	}
+	if m.rtl() {
+		slices.Reverse(menuDisplay)
+	}
 
 	// Navbar (no border, sits on top of frame)
-	navbarStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("#1a1a1a")).
+	navbarStyle := m.styles.navbar.
 		Padding(0, 1)
 
 	navbar := navbarStyle.Render(lipgloss.JoinHorizontal(lipgloss.Center, menuDisplay...))
 
 	// Content
-	subtitle := lipgloss.NewStyle().
-		Foreground(mutedColor).
+	subtitle := m.styles.muted.
 		Render(m.t("subtitle"))
-	title := titleStyle.Render(name)
+	title := m.banner(leftPanelWidth - 2)
 
 	mainContent := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -558,13 +754,6 @@ func (m model) View() string {
 		title,
 	)
 
-	// Build frame manually
-	borderColor := lipgloss.NewStyle().Foreground(primaryColor)
-
-	// Panel widths for vertical split (30% left, 70% right)
-	leftPanelWidth := innerWidth*30/100 + 1
-	rightPanelWidth := innerWidth - leftPanelWidth - 1 // -1 for the middle │
-
 	// Calculate navbar position (top right of overall view)
 	navbarLen := lipgloss.Width(navbar)
 	navbarRightPad := 1 // minimal padding from right edge
@@ -573,13 +762,20 @@ func (m model) View() string {
 		navbarPadding = 0
 	}
 
-	// Helper for clickable links (OSC 8 hyperlinks)
-	link := func(url, text string) string {
-		return fmt.Sprintf("\x1b]8;;%s\x07%s\x1b]8;;\x07", url, text)
+	// Right panel content based on selected tab
+	content := m.tabContent(m.cursor)
+	rightLines, entryStarts := renderPanel(content, rightPanelWidth)
+
+	// Breadcrumb in the dashes left of the navbar: ┬─ Tab › Entry ───┤
+	crumb := ""
+	crumbLen := 0
+	if maxCrumb := navbarPadding - 4; maxCrumb >= minCrumb {
+		crumb = ansi.Truncate(m.breadcrumb(content, entryAt(entryStarts, m.scroll)), maxCrumb, "…")
+		crumbLen = lipgloss.Width(crumb) + 3 // ─ + spaces around
 	}
 
 	// Site text box (top left)
-	siteText := " " + link("https://termfolio.dev", "termfolio.dev") + " "
+	siteText := " " + m.link("https://termfolio.dev", "termfolio.dev") + " "
 	siteLen := lipgloss.Width(siteText)
 	siteLeftPad := 1 // minimal dashes before site text
 	siteRightPad := leftPanelWidth - siteLeftPad - 2 - siteLen // -2 for ┤ and ├
@@ -588,81 +784,102 @@ func (m model) View() string {
 	}
 
 	// Site box borders
-	siteBoxTop := borderColor.Render("╭" + repeatString("─", siteLen) + "╮")
-	siteBoxBottom := borderColor.Render("╰" + repeatString("─", siteLen) + "╯")
+	siteBoxTop := b.TopLeft + repeatString(b.Top, siteLen) + b.TopRight
+	siteBoxBottom := b.BottomLeft + repeatString(b.Top, siteLen) + b.BottomRight
 
 	// Navbar box top border
-	navbarBoxTop := borderColor.Render("╭" + repeatString("─", navbarLen) + "╮")
-	navbarBoxBottom := borderColor.Render("╰" + repeatString("─", navbarLen) + "╯")
+	navbarBoxTop := b.TopLeft + repeatString(b.Top, navbarLen) + b.TopRight
+	navbarBoxBottom := b.BottomLeft + repeatString(b.Top, navbarLen) + b.BottomRight
+
+	// Frame lines are built left to right from segments, and mirrored as a
+	// whole for right-to-left languages
 
 	// Frame top line 1: site box top on left, navbar box top on right
 	// siteBoxTop's ╭ aligns with ┤ on line 2 (position siteLeftPad+1)
-	frameTopLine1 := repeatString(" ", siteLeftPad+1) +
-		siteBoxTop +
-		repeatString(" ", siteRightPad+navbarPadding+1) +
-		navbarBoxTop +
-		repeatString(" ", navbarRightPad+1)
-
-	// Frame top line 2: left border + site text + ┬ + right dashes with navbar embedded
-	frameTopLine2 := borderColor.Render("╭"+repeatString("─", siteLeftPad)+"┤") +
-		contentStyle.Render(siteText) +
-		borderColor.Render("├"+repeatString("─", siteRightPad)+"┬"+repeatString("─", navbarPadding)+"┤") +
-		navbar +
-		borderColor.Render("├"+repeatString("─", navbarRightPad)+"╮")
+	frameTopLine1 := m.frameLine(
+		textSeg(repeatString(" ", siteLeftPad+1)),
+		borderSeg(siteBoxTop),
+		textSeg(repeatString(" ", siteRightPad+navbarPadding+1)),
+		borderSeg(navbarBoxTop),
+		textSeg(repeatString(" ", navbarRightPad+1)),
+	)
+
+	// Frame top line 2: left border + site text + ┬ + breadcrumb + right dashes with navbar embedded
+	crumbSegs := []segment{}
+	if crumb != "" {
+		crumbSegs = append(crumbSegs,
+			borderSeg(b.Top),
+			textSeg(" "+m.styles.muted.Render(visualOrder(crumb))+" "),
+		)
+	}
+	frameTopLine2 := m.frameLine(slices.Concat(
+		[]segment{
+			borderSeg(b.TopLeft + repeatString(b.Top, siteLeftPad) + b.MiddleRight),
+			textSeg(m.styles.content.Render(siteText)),
+			borderSeg(b.MiddleLeft + repeatString(b.Top, siteRightPad) + b.MiddleTop),
+		},
+		crumbSegs,
+		[]segment{
+			borderSeg(repeatString(b.Top, navbarPadding-crumbLen) + b.MiddleRight),
+			textSeg(navbar),
+			borderSeg(b.MiddleLeft + repeatString(b.Top, navbarRightPad) + b.TopRight),
+		},
+	)...)
 
 	// Frame top line 3: site box bottom on left + spaces + middle │ + navbar bottom + right │
 	// siteBoxBottom's ╰ aligns with ┤ on line 2 (position siteLeftPad+1)
-	frameTopLine3 := borderColor.Render("│") +
-		repeatString(" ", siteLeftPad) +
-		siteBoxBottom +
-		repeatString(" ", siteRightPad) +
-		borderColor.Render("│") +
-		repeatString(" ", navbarPadding) +
-		navbarBoxBottom +
-		repeatString(" ", navbarRightPad) +
-		borderColor.Render("│")
+	frameTopLine3 := m.frameLine(
+		borderSeg(b.Left),
+		textSeg(repeatString(" ", siteLeftPad)),
+		borderSeg(siteBoxBottom),
+		textSeg(repeatString(" ", siteRightPad)),
+		borderSeg(b.Left),
+		textSeg(repeatString(" ", navbarPadding)),
+		borderSeg(navbarBoxBottom),
+		textSeg(repeatString(" ", navbarRightPad)),
+		borderSeg(b.Left),
+	)
 
 	frameTop := frameTopLine1 + "\n" + frameTopLine2 + "\n" + frameTopLine3
 
 	// Personal info
-	infoLabel := lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
-	infoValue := lipgloss.NewStyle().Foreground(textColor)
+	infoLabel := m.styles.title
+	infoValue := m.styles.content
 
 	// Horizontal separator line
-	separator := borderColor.Render(repeatString("─", leftPanelWidth-2))
+	separator := m.styles.frame.Render(repeatString(b.Top, leftPanelWidth-2))
 
 	// Fixed width for labels to align values
 	labelWidth := 10
 	formatLabel := func(label string) string {
-		padding := labelWidth - len(label)
+		padding := labelWidth - lipgloss.Width(label)
 		if padding < 0 {
 			padding = 0
 		}
 		return infoLabel.Render(label) + repeatString(" ", padding)
 	}
 
+	contact := contactLinks()
 	personalInfo := lipgloss.JoinVertical(
 		lipgloss.Left,
 		separator,
-		formatLabel(m.t("email"))+infoValue.Render(link("mailto:simogacha@gmail.com", "simogacha@gmail.com")),
-		formatLabel(m.t("location"))+infoValue.Render("France/Paris"),
-		formatLabel(m.t("linkedin"))+infoValue.Render(link("https://linkedin.com/in/mohamed-gacha", "linkedin.com/in/mohamed-gacha")),
-		formatLabel(m.t("github"))+infoValue.Render(link("https://github.com/MohamedGacha", "github.com/MohamedGacha")),
+		formatLabel(m.t("email"))+infoValue.Render(m.link(contact[0].url, m.markFocus(contact[0]))),
+		formatLabel(m.t("location"))+infoValue.Render(m.t("home_location")),
+		formatLabel(m.t("linkedin"))+infoValue.Render(m.link(contact[1].url, m.markFocus(contact[1]))),
+		formatLabel(m.t("github"))+infoValue.Render(m.link(contact[2].url, m.markFocus(contact[2]))),
 	)
 
 	// Biography
-	bio := lipgloss.JoinVertical(
-		lipgloss.Left,
-		"",
-		titleStyle.Render(m.t("bio_title")),
-		contentStyle.Render(m.t("about_1")),
-		contentStyle.Render(m.t("about_2")),
-		contentStyle.Render(m.t("about_3")),
-		"",
-		contentStyle.Render(m.t("about_4")),
-		contentStyle.Render(m.t("about_5")),
-		contentStyle.Render(m.t("about_6")),
-	)
+	bioLines := []string{"", m.styles.title.Render(m.t("bio_title"))}
+	for i, paragraph := range m.bio() {
+		if i > 0 {
+			bioLines = append(bioLines, "")
+		}
+		for _, line := range paragraph {
+			bioLines = append(bioLines, m.styles.content.Render(line))
+		}
+	}
+	bio := lipgloss.JoinVertical(lipgloss.Left, bioLines...)
 
 	// Left panel content (name, subtitle, personal info, and bio)
 	leftPanelContent := lipgloss.JoinVertical(
@@ -677,147 +894,10 @@ func (m model) View() string {
 		Width(leftPanelWidth).
 		Render(leftPanelContent)
 
-	// Right panel content based on selected tab
-	var rightPanelContent string
-
-	switch m.cursor {
-	case 0: // Experience
-		rightPanelContent = lipgloss.JoinVertical(
-			lipgloss.Left,
-			titleStyle.Render(m.t("exp_title"))+" "+mutedStyle.Render(m.t("exp_scroll")),
-			"",
-			titleStyle.Render("━━━ Gatewatcher ✕ "+m.t("gw_title")+" ━━━"),
-			mutedStyle.Render(m.t("gw_role")),
-			mutedStyle.Render(m.t("mission"))+" "+contentStyle.Render(m.t("gw_mission_1")),
-			contentStyle.Render(m.t("gw_mission_2")),
-			contentStyle.Render(m.t("gw_mission_3")),
-			contentStyle.Render(m.t("gw_mission_4")),
-			contentStyle.Render(m.t("gw_mission_5")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Python, Ansible, Docker, Bash, Linux, CI/CD"),
-			mutedStyle.Render(m.t("challenge"))+" "+contentStyle.Render(m.t("gw_challenge")),
-			mutedStyle.Render(m.t("feedback"))+" "+contentStyle.Render(m.t("gw_feedback_1")),
-			contentStyle.Render(m.t("gw_feedback_2")),
-			"",
-			titleStyle.Render("━━━ Etifak ✕ "+m.t("etifak_title")+" ━━━"),
-			mutedStyle.Render(m.t("etifak_role")),
-			mutedStyle.Render(m.t("mission"))+" "+contentStyle.Render(m.t("etifak_mission_1")),
-			contentStyle.Render(m.t("etifak_mission_2")),
-			contentStyle.Render(m.t("etifak_mission_3")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Python, Django, PostgreSQL, Docker, AWS EC2"),
-			mutedStyle.Render(m.t("challenge"))+" "+contentStyle.Render(m.t("etifak_challenge")),
-			mutedStyle.Render(m.t("feedback"))+" "+contentStyle.Render(m.t("etifak_feedback")),
-			"",
-			titleStyle.Render("━━━ Suez Digital Solutions ✕ "+m.t("suez_title")+" ━━━"),
-			mutedStyle.Render(m.t("suez_role")),
-			mutedStyle.Render(m.t("mission"))+" "+contentStyle.Render(m.t("suez_mission_1")),
-			contentStyle.Render(m.t("suez_mission_2")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Python, Streamlit, Pandas, asyncio, Azure DevOps"),
-			mutedStyle.Render(m.t("feedback"))+" "+contentStyle.Render(m.t("suez_feedback")),
-		)
-
-	case 1: // Education
-		rightPanelContent = lipgloss.JoinVertical(
-			lipgloss.Left,
-			titleStyle.Render(m.t("edu_title"))+" "+mutedStyle.Render(m.t("exp_scroll")),
-			"",
-			titleStyle.Render("━━━ "+m.t("ensisa_degree")+" ━━━"),
-			contentStyle.Render(m.t("ensisa_school")),
-			mutedStyle.Render(m.t("ensisa_period")+" | "+m.t("ensisa_loc")),
-			contentStyle.Render(m.t("ensisa_desc_1")),
-			contentStyle.Render(m.t("ensisa_desc_2")),
-			contentStyle.Render(m.t("ensisa_desc_3")),
-			"",
-			titleStyle.Render("━━━ "+m.t("cpge_degree")+" ━━━"),
-			contentStyle.Render(m.t("cpge_school")),
-			mutedStyle.Render(m.t("cpge_period")+" | "+m.t("cpge_loc")),
-			contentStyle.Render(m.t("cpge_desc_1")),
-			contentStyle.Render(m.t("cpge_desc_2")),
-			contentStyle.Render(m.t("cpge_desc_3")),
-			"",
-			titleStyle.Render("━━━ "+m.t("bac_degree")+" ━━━"),
-			contentStyle.Render(m.t("bac_school")),
-			mutedStyle.Render(m.t("bac_period")+" | "+m.t("bac_loc")),
-			contentStyle.Render(m.t("bac_desc_1")),
-			contentStyle.Render(m.t("bac_desc_2")),
-		)
-
-	case 2: // Projects
-		rightPanelContent = lipgloss.JoinVertical(
-			lipgloss.Left,
-			titleStyle.Render(m.t("proj_title"))+" "+mutedStyle.Render(m.t("exp_scroll")),
-			"",
-			titleStyle.Render("━━━ "+link("https://simplylovelysetups.com", "SimplyLovelySetups.com")+" ━━━"),
-			mutedStyle.Render("Sept 2025 - Present"),
-			contentStyle.Render(m.t("sls_desc_1")),
-			contentStyle.Render(m.t("sls_desc_2")),
-			contentStyle.Render(m.t("sls_desc_3")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("FastAPI, SQLAlchemy, MongoDB, NextJS, Docker"),
-			mutedStyle.Render(m.t("sls_status")),
-			"",
-			titleStyle.Render("━━━ "+link("https://termfolio.dev", "termfolio.dev")+" ━━━"),
-			mutedStyle.Render("Feb 2026 - Present"),
-			contentStyle.Render(m.t("termfolio_desc_1")),
-			contentStyle.Render(m.t("termfolio_desc_2")),
-			contentStyle.Render(m.t("termfolio_desc_3")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Go, Bubble Tea, Lipgloss, SSH (Wish)"),
-			mutedStyle.Render(m.t("termfolio_status"))+lipgloss.NewStyle().Background(primaryColor).Foreground(lipgloss.Color("#000000")).Bold(true).Render(" "+m.t("termfolio_highlight")+" "),
-			"",
-			titleStyle.Render("━━━ MealPass ━━━"),
-			mutedStyle.Render("Mars 2025"),
-			contentStyle.Render(m.t("mealpass_desc_1")),
-			contentStyle.Render(m.t("mealpass_desc_2")),
-			contentStyle.Render(m.t("mealpass_desc_3")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Django, PostgreSQL, Flutter, Dart, Docker"),
-			mutedStyle.Render(m.t("mealpass_status")),
-			"",
-			titleStyle.Render("━━━ NeoShape ━━━"),
-			mutedStyle.Render("2024"),
-			contentStyle.Render(m.t("vectorart_desc_1")),
-			contentStyle.Render(m.t("vectorart_desc_2")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Java, JavaFX"),
-			mutedStyle.Render(m.t("vectorart_status")),
-			"",
-			titleStyle.Render("━━━ Nexus ━━━"),
-			mutedStyle.Render("2024"),
-			contentStyle.Render(m.t("discordclone_desc_1")),
-			contentStyle.Render(m.t("discordclone_desc_2")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Python, Django, WebSocket, HTML/CSS"),
-			mutedStyle.Render(m.t("discordclone_status")),
-			"",
-			titleStyle.Render("━━━ NewMoodle ━━━"),
-			mutedStyle.Render("2024"),
-			contentStyle.Render(m.t("schoolmgmt_desc_1")),
-			contentStyle.Render(m.t("schoolmgmt_desc_2")),
-			mutedStyle.Render(m.t("stack"))+" "+contentStyle.Render("Python, Django, PostgreSQL, HTML/CSS"),
-			mutedStyle.Render(m.t("schoolmgmt_status")),
-		)
-
-	case 3: // Skills
-		rightPanelContent = lipgloss.JoinVertical(
-			lipgloss.Left,
-			titleStyle.Render(m.t("skills_title"))+" "+mutedStyle.Render(m.t("exp_scroll")),
-			"",
-			titleStyle.Render("Languages")+"  "+contentStyle.Render("Python, Go, Rust, TypeScript, JavaScript, Java, C, F#"),
-			titleStyle.Render("Backend")+"    "+contentStyle.Render("Django, DRF, FastAPI, PostgreSQL, MongoDB, SQLAlchemy"),
-			titleStyle.Render("Frontend")+"   "+contentStyle.Render("React.js, NextJS, Flutter, HTML/CSS"),
-			titleStyle.Render("DevOps")+"     "+contentStyle.Render("Docker, AWS, Azure, CI/CD, Linux, Ansible, Bash"),
-			titleStyle.Render("Security")+"   "+contentStyle.Render("Suricata, Network Analysis, Selenium, Beautiful Soup"),
-			titleStyle.Render("Data")+"       "+contentStyle.Render("Pandas, Streamlit, Azure ML"),
-			titleStyle.Render("Tools")+"      "+contentStyle.Render("Git, Scrum/Agile, Code Review, REST APIs"),
-		)
-
-	}
-
-	rightContent := lipgloss.NewStyle().
-		PaddingLeft(1).
-		Width(rightPanelWidth).
-		Render(rightPanelContent)
-
 	// Split content into lines
 	leftLines := splitLines(leftContent)
-	rightLines := splitLines(rightContent)
 
-	contentHeight := height - 7 // Leave space for top (3 lines) + bottom (3 lines) + margin
+	contentHeight := l.contentHeight
 
 	// Clamp scroll to valid range
 	maxScroll := len(rightLines) - contentHeight
@@ -841,63 +921,75 @@ func (m model) View() string {
 			rightLine = rightLines[rightIdx]
 		}
 		// Pad lines to correct width
-		leftLineWidth := lipgloss.Width(leftLine)
-		if leftLineWidth < leftPanelWidth {
-			leftLine += repeatString(" ", leftPanelWidth-leftLineWidth)
-		}
-		rightLineWidth := lipgloss.Width(rightLine)
-		if rightLineWidth < rightPanelWidth {
-			rightLine += repeatString(" ", rightPanelWidth-rightLineWidth)
-		}
-		framedContent += borderColor.Render("│") + leftLine + borderColor.Render("│") + rightLine + borderColor.Render("│") + "\n"
+		framedContent += m.frameLine(
+			borderSeg(b.Left),
+			textSeg(m.panelLine(leftLine, leftPanelWidth)),
+			borderSeg(b.Left),
+			textSeg(m.panelLine(rightLine, rightPanelWidth)),
+			borderSeg(b.Left),
+		) + "\n"
 	}
 
-	// Language selector box (bottom left, integrated into border)
-	var langEN, langFR string
-	if m.lang == EN {
-		langEN = titleStyle.Render(" EN ")
-		langFR = mutedStyle.Render(" FR ")
-	} else {
-		langEN = mutedStyle.Render(" EN ")
-		langFR = titleStyle.Render(" FR ")
+	// Language selector box (bottom left, integrated into border), one
+	// cell per registered language: ┤ EN │ FR │ ES ├
+	langLeftPad := 1
+	selectorLangs := langCodes()
+	if 5*len(selectorLangs)+1 > leftPanelWidth-langLeftPad-1 {
+		// Too many to fit the left panel: show the active one only
+		selectorLangs = []string{string(m.lang)}
+	}
+
+	var langCells []segment
+	var cellDashes []string
+	for i, code := range selectorLangs {
+		if i > 0 {
+			langCells = append(langCells, borderSeg(b.Left))
+		}
+		label := " " + strings.ToUpper(code) + " "
+		if Lang(code) == m.lang {
+			langCells = append(langCells, textSeg(m.styles.title.Render(label)))
+		} else {
+			langCells = append(langCells, textSeg(m.styles.muted.Render(label)))
+		}
+		cellDashes = append(cellDashes, repeatString(b.Top, lipgloss.Width(label)))
 	}
 
-	// Lang selector parts (visual width = 11)
-	// ╭────┬────╮ = 11 chars
-	// ┤ EN │ FR ├ = 11 chars (┤ + 4 + │ + 4 + ├)
-	// ╰────┴────╯ = 11 chars
-	langSelectorTop := "╭────┬────╮"
-	langSelectorBot := "╰────┴────╯"
-	langWidth := 11
+	// Lang selector parts, e.g. for two languages (visual width = 11)
+	// ╭────┬────╮
+	// ┤ EN │ FR ├
+	// ╰────┴────╯
+	langSelectorTop := b.TopLeft + strings.Join(cellDashes, b.MiddleTop) + b.TopRight
+	langSelectorBot := b.BottomLeft + strings.Join(cellDashes, b.MiddleBottom) + b.BottomRight
+	langWidth := lipgloss.Width(langSelectorTop)
 
 	// Position lang selector at bottom left (minimal padding)
-	langLeftPad := 1
 	langRightPadLeft := leftPanelWidth - langWidth - langLeftPad
 	if langRightPadLeft < 0 {
 		langRightPadLeft = 0
 	}
 
 	// Bottom line 1: │ + spaces + lang box top + spaces + │ + right spaces + │
-	frameBottomLine1 := borderColor.Render("│") +
-		repeatString(" ", langLeftPad) +
-		borderColor.Render(langSelectorTop) +
-		repeatString(" ", langRightPadLeft) +
-		borderColor.Render("│") +
-		repeatString(" ", rightPanelWidth) +
-		borderColor.Render("│")
+	frameBottomLine1 := m.frameLine(
+		borderSeg(b.Left),
+		textSeg(repeatString(" ", langLeftPad)),
+		borderSeg(langSelectorTop),
+		textSeg(repeatString(" ", langRightPadLeft)),
+		borderSeg(b.Left),
+		textSeg(repeatString(" ", rightPanelWidth)),
+		borderSeg(b.Left),
+	)
 
 	// Bottom line 2: main bottom border with lang selector embedded
 	// ╰ + dashes + ┤ + EN + │ + FR + ├ + dashes + ┴ + dashes + ╯
-	frameBottomLine2 := borderColor.Render("╰"+repeatString("─", langLeftPad)+"┤") +
-		langEN +
-		borderColor.Render("│") +
-		langFR +
-		borderColor.Render("├"+repeatString("─", langRightPadLeft)+"┴"+repeatString("─", rightPanelWidth)+"╯")
+	frameBottomLine2 := m.frameLine(slices.Concat(
+		[]segment{borderSeg(b.BottomLeft + repeatString(b.Top, langLeftPad) + b.MiddleRight)},
+		langCells,
+		[]segment{borderSeg(b.MiddleLeft + repeatString(b.Top, langRightPadLeft) + b.MiddleBottom + repeatString(b.Top, rightPanelWidth) + b.BottomRight)},
+	)...)
 
 	// Bottom line 3: lang selector bottom box on left + footer centered
-	footer := lipgloss.NewStyle().
-		Foreground(mutedColor).
-		Render(m.t("footer"))
+	footer := m.styles.muted.
+		Render(visualOrder(m.t("footer")))
 
 	// Calculate total frame width
 	totalFrameWidth := leftPanelWidth + rightPanelWidth + 3 // +3 for │ characters
@@ -905,6 +997,13 @@ func (m model) View() string {
 	// Lang selector bottom takes: langLeftPad + 1 (for initial space) + langWidth
 	langSelectorEnd := langLeftPad + 1 + langWidth
 
+	// A toast takes the footer's place; either is cut to the room right of
+	// the selector so that it never wraps the frame
+	if m.toast != "" {
+		footer = m.styles.title.Render(visualOrder(m.toast))
+	}
+	footer = ansi.Truncate(footer, totalFrameWidth-langSelectorEnd-3, "…")
+
 	// Center footer in the remaining space (or full width)
 	footerWidth := lipgloss.Width(footer)
 	footerStart := (totalFrameWidth - footerWidth) / 2
@@ -915,28 +1014,35 @@ func (m model) View() string {
 	}
 
 	// Build line 3
-	frameBottomLine3 := repeatString(" ", langLeftPad+1) +
-		borderColor.Render(langSelectorBot) +
-		repeatString(" ", footerStart-langSelectorEnd) +
-		footer +
-		repeatString(" ", totalFrameWidth-footerStart-footerWidth)
+	frameBottomLine3 := m.frameLine(
+		textSeg(repeatString(" ", langLeftPad+1)),
+		borderSeg(langSelectorBot),
+		textSeg(repeatString(" ", footerStart-langSelectorEnd)),
+		textSeg(footer),
+		textSeg(repeatString(" ", totalFrameWidth-footerStart-footerWidth)),
+	)
 
 	frameBottom := frameBottomLine1 + "\n" + frameBottomLine2 + "\n" + frameBottomLine3
 
 	// Combine
 	fullView := frameTop + "\n" + framedContent + frameBottom
+	if m.picker {
+		fullView = overlayCenter(fullView, m.pickerView())
+	}
+	if m.qr {
+		fullView = overlayCenter(fullView, m.qrView())
+	}
+	if m.rtl() && !m.plain {
+		fullView = enableExplicitBidi + fullView
+	}
 
 	// Center horizontally
-	return lipgloss.PlaceHorizontal(width, lipgloss.Center, fullView)
+	return m.toASCII(lipgloss.PlaceHorizontal(width, lipgloss.Center, fullView))
 }
 
 // cursorBlock renders a character with inverted colors to simulate a terminal block cursor.
-func cursorBlock(ch string) string {
-	return lipgloss.NewStyle().
-		Background(primaryColor).
-		Foreground(lipgloss.Color("#000000")).
-		Bold(true).
-		Render(ch)
+func (m model) cursorBlock(ch string) string {
+	return m.styles.highlight.Render(ch)
 }
 
 func (m model) welcomeView() string {
@@ -956,9 +1062,7 @@ func (m model) welcomeView() string {
 	}
 
 	// Style the text
-	animStyle := lipgloss.NewStyle().
-		Foreground(primaryColor).
-		Bold(true)
+	animStyle := m.styles.title
 
 	// Build display with cursor at the correct insertion point
 	fullText := prependText + domainText
@@ -973,19 +1077,19 @@ func (m model) welcomeView() string {
 		line = animStyle.Render(domainText + cursor)
 	case PhaseCursorHome:
 		if m.cursorOn {
-			line = cursorBlock("t") + animStyle.Render(domainText[1:]+" ")
+			line = m.cursorBlock("t") + animStyle.Render(domainText[1:]+" ")
 		} else {
 			line = animStyle.Render(domainText + " ")
 		}
 	case PhasePrepend:
 		if m.cursorOn {
-			line = animStyle.Render(prependText[:m.animPos]) + cursorBlock("t") + animStyle.Render(domainText[1:]+" ")
+			line = animStyle.Render(prependText[:m.animPos]) + m.cursorBlock("t") + animStyle.Render(domainText[1:]+" ")
 		} else {
 			line = animStyle.Render(prependText[:m.animPos] + domainText + " ")
 		}
 	case PhasePauseAfterPrepend:
 		if m.cursorOn {
-			line = animStyle.Render(prependText) + cursorBlock("t") + animStyle.Render(domainText[1:]+" ")
+			line = animStyle.Render(prependText) + m.cursorBlock("t") + animStyle.Render(domainText[1:]+" ")
 		} else {
 			line = animStyle.Render(fullText + " ")
 		}
@@ -1000,7 +1104,7 @@ func (m model) welcomeView() string {
 		line = animStyle.Render(cursor)
 	}
 
-	hint := mutedStyle.Render("Press any key to continue")
+	hint := m.styles.muted.Render(visualOrder(m.t("welcome_hint")))
 
 	centeredLine := lipgloss.PlaceHorizontal(width, lipgloss.Center, line)
 	centeredHint := lipgloss.PlaceHorizontal(width, lipgloss.Center, hint)
@@ -1012,7 +1116,11 @@ func (m model) welcomeView() string {
 		centeredHint,
 	)
 
-	return lipgloss.PlaceVertical(height, lipgloss.Center, content)
+	view := lipgloss.PlaceVertical(height, lipgloss.Center, content)
+	if m.rtl() && !m.plain {
+		view = enableExplicitBidi + view
+	}
+	return view
 }
 
 func repeatString(s string, count int) string {
@@ -1043,21 +1151,115 @@ func splitLines(s string) []string {
 	return lines
 }
 
-func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
-	return initialModel(), []tea.ProgramOption{tea.WithAltScreen()}
+// Session handler; the visitor's locale (ssh -o SendEnv=LANG) picks the
+// language unless --lang forced one. Styles are rendered for the visitor's
+// terminal: its color profile (TERM, COLORTERM, NO_COLOR) and background,
+// and ASCII replaces other glyphs on terminals limited to it unless --ascii
+// forced that for everyone.
+func teaHandler(forced Lang, theme int, ascii bool) bm.Handler {
+	return func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+		lang := forced
+		if lang == "" {
+			lang = langFromEnv(s.Environ())
+		}
+		m := initialModel(lang)
+		m.setRenderer(bm.MakeRenderer(s))
+		m.setTheme(initialTheme(theme, m.renderer))
+		pty, _, _ := s.Pty()
+		m.ascii = ascii || asciiTerminal(pty.Term, s.Environ())
+		m.hyperlinks = hyperlinkTerminal(pty.Term, s.Environ())
+		return m, []tea.ProgramOption{tea.WithAltScreen()}
+	}
 }
 
+// Commands termfolio runs, listed by -h and for an unknown command
+const usage = `Usage:
+  termfolio [flags]                 show the resume in this terminal
+  termfolio --ssh [flags]           serve it over SSH on port 23234
+  termfolio print [flags]           write one frame of a tab
+  termfolio export --format <format> [flags]
+  termfolio import linkedin <archive.zip> [--output resume.toml]
+  termfolio record [--themes <dir>] <script.tape> <out.cast>
+  termfolio i18n check | review <lang> [<base lang>]
+
+Commands take -h for their flags.
+`
+
 func main() {
+	// Subcommands come before flags: termfolio i18n check
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		var err error
+		switch os.Args[1] {
+		case "i18n":
+			err = i18nCommand(os.Args[2:])
+		case "record":
+			err = recordCommand(os.Args[2:])
+		case "print":
+			err = printCommand(os.Args[2:])
+		case "import":
+			err = importCommand(os.Args[2:])
+		case "export":
+			err = exportCommand(os.Args[2:])
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", os.Args[1], usage)
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	sshMode := flag.Bool("ssh", false, "Start SSH server mode")
+	langFlag := flag.String("lang", "", "Interface language ("+strings.Join(langCodes(), ", ")+"); defaults to the locale")
+	themeFlag := flag.String("theme", "", "Color theme ("+strings.Join(themeNames(), ", ")+", or a user theme)")
+	configFile := flag.String("config", filepath.Join(configDir(), "config.toml"), "Settings file")
+	themesDir := flag.String("themes", themeDir(), "Directory of user themes (*.toml)")
+	resumeFile := flag.String("resume", filepath.Join(configDir(), "resume.toml"), "Resume file; defaults to the built-in resume when missing")
+	asciiFlag := flag.Bool("ascii", false, "Draw with ASCII characters only; by default chosen from TERM and the locale")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s\nFlags:\n", usage)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
+	if flag.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", flag.Arg(0), usage)
+		os.Exit(2)
+	}
+
+	if err := loadConfig(*configFile); err != nil {
+		log.Print(err)
+	}
+	if err := loadResume(*resumeFile); err != nil {
+		log.Print(err)
+	}
+	for _, err := range loadThemes(*themesDir) {
+		log.Print(err)
+	}
 
 	if *sshMode {
+		var forced Lang
+		if *langFlag != "" {
+			lang, err := startLang(*langFlag)
+			if err != nil {
+				log.Fatalf("Invalid --lang: %v", err)
+			}
+			forced = lang
+		}
+
+		theme, err := startTheme(*themeFlag)
+		if err != nil {
+			log.Fatalf("Invalid --theme: %v", err)
+		}
+
 		s, err := wish.NewServer(
 			wish.WithAddress(net.JoinHostPort("0.0.0.0", "23234")),
 			wish.WithHostKeyPath(".ssh/termfolio_ed25519"),
 			wish.WithMiddleware(
-				bm.Middleware(teaHandler),
-				activeterm.Middleware(),
+				bm.Middleware(teaHandler(forced, theme, *asciiFlag)),
+				bidiMiddleware,
+				exportMiddleware(forced),
 				lm.Middleware(),
 			),
 		)
@@ -1083,8 +1285,33 @@ func main() {
 			log.Fatalf("Could not gracefully shut down server: %v", err)
 		}
 	} else {
-		p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
+		lang, err := startLang(*langFlag)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		theme, err := startTheme(*themeFlag)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		m := initialModel(lang)
+		m.setTheme(initialTheme(theme, m.renderer))
+		m.ascii = *asciiFlag || asciiTerminal(os.Getenv("TERM"), os.Environ())
+		m.hyperlinks = hyperlinkTerminal(os.Getenv("TERM"), os.Environ())
+
+		// Piped or redirected (termfolio | less, termfolio > cv.txt): the
+		// resume as text, as the alt screen would only garble it
+		if !term.IsTerminal(os.Stdout.Fd()) {
+			fmt.Print(m.plainText(pipedWidth()))
+			return
+		}
+		p := tea.NewProgram(m, tea.WithAltScreen())
+		_, err = p.Run()
+		fmt.Print(resetExplicitBidi)
+		if err != nil {
 			fmt.Printf("Error: %v", err)
 			os.Exit(1)
 		}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Escapes the characters Markdown would take for formatting or links
+var mdEscape = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`)
+
+func mdLink(text, url string) string {
+	if url == "" {
+		return mdEscape.Replace(text)
+	}
+	return "[" + mdEscape.Replace(text) + "](" + url + ")"
+}
+
+// Writes the resume as Markdown (export --format md), e.g. for a README
+func writeMarkdown(w io.Writer, o exportOptions) error {
+	m := o.model()
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n**%s**\n\n", mdEscape.Replace(owner.Name), mdEscape.Replace(m.t("subtitle")))
+	var details []string
+	for _, l := range m.contactDetails() {
+		details = append(details, mdLink(l.text, l.url))
+	}
+	b.WriteString(strings.Join(details, " · ") + "\n")
+
+	fmt.Fprintf(&b, "\n## %s\n", mdEscape.Replace(m.t("bio_title")))
+	for _, paragraph := range m.bio() {
+		b.WriteString("\n" + mdEscape.Replace(strings.Join(paragraph, " ")) + "\n")
+	}
+
+	for _, s := range m.resume() {
+		fmt.Fprintf(&b, "\n## %s\n\n", mdEscape.Replace(s.title))
+		if s.compact {
+			for _, e := range s.entries {
+				fmt.Fprintf(&b, "- **%s:** %s\n", mdEscape.Replace(e.title), mdEscape.Replace(e.blocks[0].text()))
+			}
+			continue
+		}
+
+		for i, e := range s.entries {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			title := mdLink(e.title, e.url)
+			if e.org != "" {
+				title += " — " + mdEscape.Replace(e.org)
+			}
+			fmt.Fprintf(&b, "### %s\n", title)
+			for _, block := range e.blocks {
+				b.WriteString("\n" + mdBlock(block) + "\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// A block as a paragraph: meta lines in italics, labels in bold and tags
+// as code spans
+func mdBlock(b resumeBlock) string {
+	var text string
+	switch b.kind {
+	case blockMeta:
+		return "*" + mdEscape.Replace(b.text()) + "*"
+	case blockTags:
+		tags := make([]string, len(b.tags))
+		for i, tag := range b.tags {
+			tags[i] = "`" + tag + "`"
+		}
+		text = strings.Join(tags, " ")
+	default:
+		text = mdEscape.Replace(b.text())
+	}
+	if b.label != "" {
+		text = "**" + mdEscape.Replace(b.label) + "** " + text
+	}
+	return text
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/lucasb-eyer/go-colorful"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+
+	"termfolio/data"
+)
+
+// Page layout of the PDF resume, in millimeters and points
+const (
+	pdfMargin     = 15.0
+	pdfLineHeight = 4.2 // body text
+	pdfBodySize   = 9.0
+	pdfTagHeight  = 4.2
+)
+
+// Theme colors made readable on paper
+type paperColors struct {
+	accent, rule, text, muted, tag colorful.Color
+}
+
+// Colors of a theme for a white page. Themes are made for a terminal
+// background, often dark, so light colors are darkened until they stand
+// out: to near black for text, less for the accents.
+func newPaperColors(p palette) paperColors {
+	accent := onPaper(p.Primary, 0.18)
+	return paperColors{
+		accent: accent,
+		rule:   onPaper(p.Secondary, 0.3),
+		text:   onPaper(p.Text, 0.02),
+		muted:  onPaper(p.Muted, 0.15),
+		tag:    accent.BlendRgb(colorful.Color{R: 1, G: 1, B: 1}, 0.88),
+	}
+}
+
+// A theme color darkened to at most the given relative luminance
+func onPaper(color string, luminance float64) colorful.Color {
+	c := paletteColor(color)
+	for {
+		r, g, b := c.LinearRgb()
+		if 0.2126*r+0.7152*g+0.0722*b <= luminance {
+			return c
+		}
+		c = c.BlendRgb(colorful.Color{}, 0.1)
+	}
+}
+
+// A PDF being laid out, which keeps the text it writes to check the fonts
+// have its glyphs
+type pdfDoc struct {
+	*fpdf.Fpdf
+	colors paperColors
+	text   strings.Builder
+}
+
+func (d *pdfDoc) color(c colorful.Color) {
+	r, g, b := c.RGB255()
+	d.SetTextColor(int(r), int(g), int(b))
+}
+
+func (d *pdfDoc) font(style string, size float64, c colorful.Color) {
+	d.SetFont("go", style, size)
+	d.color(c)
+}
+
+// Flowing text, wrapped at the right margin and linked when url is set
+func (d *pdfDoc) write(h float64, s, url string) {
+	d.text.WriteString(s)
+	if url != "" {
+		d.WriteLinkString(h, s, url)
+	} else {
+		d.Write(h, s)
+	}
+}
+
+// Breaks the page unless height millimeters are left on it, keeping a
+// heading with what follows
+func (d *pdfDoc) keep(height float64) {
+	_, pageHeight := d.GetPageSize()
+	if d.GetY()+height > pageHeight-pdfMargin {
+		d.AddPage()
+	}
+}
+
+// Section heading over a rule in the theme's second color
+func (d *pdfDoc) heading(title string) {
+	d.keep(22)
+	d.Ln(2)
+	d.font("B", 12.5, d.colors.accent)
+	d.write(7, strings.ToUpper(title), "")
+	d.Ln(7.5)
+	r, g, b := d.colors.rule.RGB255()
+	d.SetDrawColor(int(r), int(g), int(b))
+	d.SetLineWidth(0.3)
+	pageWidth, _ := d.GetPageSize()
+	d.Line(pdfMargin, d.GetY(), pageWidth-pdfMargin, d.GetY())
+	d.Ln(2.5)
+}
+
+// Tags as rounded labels from the current position, wrapping back to left
+func (d *pdfDoc) tags(tags []string, left float64) {
+	d.font("", 8, d.colors.accent)
+	fr, fg, fb := d.colors.tag.RGB255()
+	d.SetFillColor(int(fr), int(fg), int(fb))
+	pageWidth, _ := d.GetPageSize()
+
+	x, y := d.GetX(), d.GetY()
+	for _, tag := range tags {
+		d.text.WriteString(tag)
+		w := d.GetStringWidth(tag) + 3
+		if x+w > pageWidth-pdfMargin {
+			x, y = left, y+pdfTagHeight+1
+		}
+		d.RoundedRect(x, y, w, pdfTagHeight, 1.2, "1234", "F")
+		d.SetXY(x, y)
+		d.CellFormat(w, pdfTagHeight, tag, "", 0, "C", false, 0, "")
+		x += w + 1.5
+	}
+	d.SetXY(pdfMargin, y+pdfTagHeight+1)
+}
+
+func (d *pdfDoc) block(b resumeBlock) {
+	switch b.kind {
+	case blockMeta:
+		d.font("I", 8.6, d.colors.muted)
+		d.write(4, b.text(), "")
+		d.Ln(4.3)
+	case blockTags:
+		if b.label != "" {
+			d.font("B", pdfBodySize, d.colors.text)
+			d.write(pdfTagHeight, b.label+" ", "")
+			d.SetX(d.GetX() + 1)
+		}
+		d.tags(b.tags, pdfMargin)
+	default:
+		if b.label != "" {
+			d.font("B", pdfBodySize, d.colors.text)
+			d.write(pdfLineHeight, b.label+" ", "")
+		}
+		d.font("", pdfBodySize, d.colors.text)
+		d.write(pdfLineHeight, b.text(), "")
+		d.Ln(pdfLineHeight + 0.5)
+	}
+}
+
+func (d *pdfDoc) entry(e resumeEntry) {
+	d.keep(20)
+	d.font("B", 10.5, d.colors.text)
+	if e.url != "" {
+		d.color(d.colors.accent)
+	}
+	d.write(5.5, e.title, e.url)
+	if e.org != "" {
+		d.font("", 10.5, d.colors.accent)
+		d.write(5.5, " — "+e.org, "")
+	}
+	d.Ln(5.8)
+	for _, b := range e.blocks {
+		d.block(b)
+	}
+	d.Ln(1.8)
+}
+
+// The header: name, title, then the contact details as links
+func (d *pdfDoc) header(c data.Contact) {
+	d.font("B", 22, d.colors.accent)
+	d.write(10, c.Name, "")
+	d.Ln(10)
+	d.font("", 12, d.colors.text)
+	d.write(6, c.Title, "")
+	d.Ln(7.5)
+
+	// Email, phone, location, then the profiles
+	contact := contactLinks()
+	details := []focusLink{contact[0]}
+	if c.Phone != "" {
+		details = append(details, focusLink{"tel:" + strings.ReplaceAll(c.Phone, " ", ""), c.Phone})
+	}
+	details = append(details, focusLink{"", c.Location})
+	details = append(details, contact[1:]...)
+
+	d.font("", 8.5, d.colors.muted)
+	for i, l := range details {
+		if i > 0 {
+			d.color(d.colors.muted)
+			d.write(4.5, " · ", "")
+		}
+		if l.url != "" {
+			d.color(d.colors.accent)
+		}
+		d.write(4.5, l.text, l.url)
+	}
+	d.Ln(6)
+
+	r, g, b := d.colors.accent.RGB255()
+	d.SetDrawColor(int(r), int(g), int(b))
+	d.SetLineWidth(0.6)
+	pageWidth, _ := d.GetPageSize()
+	d.Line(pdfMargin, d.GetY(), pageWidth-pdfMargin, d.GetY())
+	d.Ln(3)
+}
+
+// Sections of the resume data as the PDF lays them out, with the labels of
+// the model's language
+func pdfSections(m model, r data.Resume) []resumeSection {
+	experience := resumeSection{title: m.t("exp_title")}
+	for _, e := range r.Experiences {
+		var where []string
+		for _, s := range []string{e.Location, e.Period} {
+			if s != "" {
+				where = append(where, s)
+			}
+		}
+		blocks := []resumeBlock{metaBlock(strings.Join(where, " | "))}
+		if e.Desc != "" {
+			blocks = append(blocks, textBlock(m.t("mission"), e.Desc))
+		}
+		if len(e.Tags) > 0 {
+			blocks = append(blocks, tagsBlock(m.t("stack"), e.Tags...))
+		}
+		experience.entries = append(experience.entries, resumeEntry{e.Company, e.Company, e.Title, "", blocks})
+	}
+
+	education := resumeSection{title: m.t("edu_title")}
+	for _, e := range r.Education {
+		blocks := []resumeBlock{textBlock("", e.School), metaBlock(e.Period)}
+		if e.Desc != "" {
+			blocks = append(blocks, textBlock("", e.Desc))
+		}
+		education.entries = append(education.entries, resumeEntry{e.School, "", e.Degree, "", blocks})
+	}
+
+	projects := resumeSection{title: m.t("proj_title")}
+	for _, p := range r.Projects {
+		blocks := []resumeBlock{metaBlock(p.Date)}
+		if p.Desc != "" {
+			blocks = append(blocks, textBlock("", p.Desc))
+		}
+		if len(p.Tags) > 0 {
+			blocks = append(blocks, tagsBlock(m.t("stack"), p.Tags...))
+		}
+		projects.entries = append(projects.entries, resumeEntry{p.Name, "", p.Name, p.URL, blocks})
+	}
+
+	return []resumeSection{experience, education, projects}
+}
+
+// Writes the resume data as an A4 PDF (export --format pdf) in the theme's
+// colors, with the Go fonts embedded. The labels are in the language of
+// --lang, the content in the one the resume data is written in. Languages
+// those fonts can't draw, such as Arabic, are refused rather than printed
+// as empty boxes.
+func writePDF(w io.Writer, o exportOptions) error {
+	m := o.model()
+	d := &pdfDoc{Fpdf: fpdf.New("P", "mm", "A4", ""), colors: newPaperColors(themes[m.theme].Colors)}
+	d.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	d.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	d.AddUTF8FontFromBytes("go", "I", goitalic.TTF)
+	d.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	d.SetAutoPageBreak(true, pdfMargin)
+	r := data.GetResume()
+	d.SetTitle(r.Contact.Name+" — "+r.Contact.Title, true)
+	d.SetAuthor(r.Contact.Name, true)
+	d.SetCreator("termfolio", true)
+	d.SetLang(string(o.lang))
+	d.AliasNbPages("")
+	d.SetFooterFunc(func() {
+		d.SetY(-pdfMargin + 4)
+		d.font("", 7.5, d.colors.muted)
+		d.CellFormat(0, 4, fmt.Sprintf("%d/{nb}", d.PageNo()), "", 0, "R", false, 0, "")
+	})
+	d.AddPage()
+
+	d.header(r.Contact)
+	for _, s := range pdfSections(m, r) {
+		if len(s.entries) == 0 {
+			continue
+		}
+		d.heading(s.title)
+		for _, e := range s.entries {
+			d.entry(e)
+		}
+	}
+	if len(r.Skills) > 0 {
+		d.heading(m.t("skills_title"))
+		d.tags(r.Skills, pdfMargin)
+	}
+
+	if missing := missingGlyphs(d.text.String()); len(missing) > 0 {
+		return fmt.Errorf("pdf: the embedded fonts have no glyphs for %d characters of %s, such as %q", len(missing), o.lang, missing[0])
+	}
+	return d.Output(w)
+}
+
+// Characters of s the Go fonts have no glyph for
+func missingGlyphs(s string) []rune {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		return nil
+	}
+	var buf sfnt.Buffer
+	var missing []rune
+	for _, r := range s {
+		if unicode.IsSpace(r) || slices.Contains(missing, r) {
+			continue
+		}
+		if i, err := f.GlyphIndex(&buf, r); err != nil || i == 0 {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+)
+
+// Line width of the plain-text export, short enough to quote in an email
+const plainTextWidth = 76
+
+// Width of the plain-text resume printed instead of the TUI when standard
+// output isn't a terminal: that of the terminal the command was typed in, as
+// in termfolio | less, then $COLUMNS, then the export's width
+func pipedWidth() int {
+	for _, f := range []*os.File{os.Stdin, os.Stderr} {
+		if w, _, err := term.GetSize(f.Fd()); err == nil && w > 0 {
+			return w
+		}
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return plainTextWidth
+}
+
+// Width of the plain-text resume sent to an SSH session without a terminal:
+// $COLUMNS when the client sends it, else the export's width
+func environWidth(environ []string) int {
+	for _, kv := range environ {
+		if v, ok := strings.CutPrefix(kv, "COLUMNS="); ok {
+			if w, err := strconv.Atoi(v); err == nil && w > 0 {
+				return w
+			}
+		}
+	}
+	return plainTextWidth
+}
+
+// Wraps text at width, indenting every line but the first by indent columns
+func hangingWrap(text string, width, indent int) string {
+	lines := strings.Split(ansi.Wrap(text, max(width-indent, 10), ""), "\n")
+	return strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+// The resume as plain text wrapped at width: headings underlined with =,
+// links spelled out and no styles, for mail and files
+func (m model) plainText(width int) string {
+	var b strings.Builder
+	heading := func(title string) {
+		title = strings.ToUpper(title)
+		b.WriteString("\n" + title + "\n" + strings.Repeat("=", lipgloss.Width(title)) + "\n")
+	}
+
+	b.WriteString(owner.Name + "\n" + hangingWrap(m.t("subtitle"), width, 0) + "\n\n")
+	for _, l := range m.contactDetails() {
+		text := l.text
+		if strings.HasPrefix(l.url, "https://") {
+			text = l.url
+		}
+		b.WriteString(text + "\n")
+	}
+
+	heading(m.t("bio_title"))
+	for _, paragraph := range m.bio() {
+		b.WriteString("\n" + hangingWrap(strings.Join(paragraph, " "), width, 0) + "\n")
+	}
+
+	for _, s := range m.resume() {
+		heading(s.title)
+		b.WriteString("\n")
+		if s.compact {
+			// Skill lists line up after the longest group name
+			nameWidth := 0
+			for _, e := range s.entries {
+				nameWidth = max(nameWidth, lipgloss.Width(e.title))
+			}
+			for _, e := range s.entries {
+				pad := strings.Repeat(" ", nameWidth-lipgloss.Width(e.title)+2)
+				b.WriteString(e.title + pad + hangingWrap(e.blocks[0].text(), width, nameWidth+2) + "\n")
+			}
+			continue
+		}
+
+		for i, e := range s.entries {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			title := e.title
+			if e.org != "" {
+				title += " — " + e.org
+			}
+			b.WriteString(hangingWrap(title, width, 0) + "\n")
+			if e.url != "" && !strings.EqualFold(strings.TrimPrefix(e.url, "https://"), e.title) {
+				b.WriteString(e.url + "\n")
+			}
+			for _, block := range e.blocks {
+				text := block.text()
+				if block.label != "" {
+					text = block.label + " " + text
+				}
+				b.WriteString(hangingWrap(text, width, 0) + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// Writes the resume as plain text (export --format txt)
+func writePlainText(w io.Writer, o exportOptions) error {
+	_, err := io.WriteString(w, o.model().plainText(plainTextWidth))
+	return err
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Names of the tabs on the command line, in navbar order
+var tabNames = []string{"experience", "education", "projects", "skills"}
+
+// Position of a tab by name, or by number from 1
+func tabIndex(name string) (int, error) {
+	for i, tab := range tabNames {
+		if strings.EqualFold(tab, name) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil && n >= 1 && n <= len(tabNames) {
+		return n - 1, nil
+	}
+	return 0, fmt.Errorf("unknown tab %q (available: %s)", name, strings.Join(tabNames, ", "))
+}
+
+// Parses a terminal size written WIDTHxHEIGHT, e.g. 120x40
+func parseSize(s string) (width, height int, err error) {
+	w, h, ok := strings.Cut(strings.ToLower(s), "x")
+	if ok {
+		width, err = strconv.Atoi(w)
+	}
+	if ok && err == nil {
+		height, err = strconv.Atoi(h)
+	}
+	if !ok || err != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid size %q: want WIDTHxHEIGHT, e.g. 120x40", s)
+	}
+	return width, height, nil
+}
+
+// What a single frame of the TUI is rendered with
+type frameOptions struct {
+	lang          Lang
+	theme         int // index in themes
+	tab           int // index in tabNames
+	width, height int
+
+	// Without color the frame has no escape sequences: no colors or styles,
+	// and footnotes in place of hyperlinks
+	color bool
+	ascii bool
+
+	// Start on the welcome screen rather than the tab
+	welcome bool
+}
+
+// The model on the tab and at the size of the options, past the welcome
+// screen unless they start on it, as a truecolor terminal would show it
+func (o frameOptions) model() model {
+	m := initialModel(o.lang)
+	r := lipgloss.NewRenderer(io.Discard)
+	if o.color {
+		r.SetColorProfile(termenv.TrueColor)
+	} else {
+		r.SetColorProfile(termenv.Ascii)
+	}
+	m.renderer = r
+	m.setTheme(o.theme)
+	m.ascii = o.ascii
+	m.hyperlinks = o.color
+	m.plain = !o.color
+	if !o.welcome {
+		m.screen = PortfolioScreen
+	}
+	m.cursor = o.tab
+	next, _ := m.Update(tea.WindowSizeMsg{Width: o.width, Height: o.height})
+	return next.(model)
+}
+
+// The frame the options describe
+func (o frameOptions) frame() string {
+	return o.model().View()
+}
+
+// termfolio print [--tab <tab>] [--lang <lang>] [--size WIDTHxHEIGHT]
+// [--theme <theme>] [--no-color] [--ascii]
+func printCommand(args []string) error {
+	fs := flag.NewFlagSet("print", flag.ContinueOnError)
+	tabFlag := fs.String("tab", tabNames[0], "Tab to show ("+strings.Join(tabNames, ", ")+")")
+	langFlag := fs.String("lang", "", "Interface language; defaults to the locale")
+	sizeFlag := fs.String("size", "80x24", "Terminal size, WIDTHxHEIGHT")
+	themeFlag := fs.String("theme", "", "Color theme ("+strings.Join(themeNames(), ", ")+", or a user theme)")
+	themesDir := fs.String("themes", themeDir(), "Directory of user themes (*.toml)")
+	resumeFile := fs.String("resume", filepath.Join(configDir(), "resume.toml"), "Resume file; defaults to the built-in resume when missing")
+	noColor := fs.Bool("no-color", false, "Write plain text: no colors, styles or hyperlinks")
+	ascii := fs.Bool("ascii", false, "Draw with ASCII characters only")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	tab, err := tabIndex(*tabFlag)
+	if err != nil {
+		return err
+	}
+	width, height, err := parseSize(*sizeFlag)
+	if err != nil {
+		return err
+	}
+	if width < minWidth || height < minHeight {
+		return fmt.Errorf("size %s is below the minimum of %dx%d", *sizeFlag, minWidth, minHeight)
+	}
+	lang, err := startLang(*langFlag)
+	if err != nil {
+		return err
+	}
+	if err := loadResume(*resumeFile); err != nil {
+		return err
+	}
+	for _, err := range loadThemes(*themesDir) {
+		log.Print(err)
+	}
+	theme, err := startTheme(*themeFlag)
+	if err != nil {
+		return err
+	}
+
+	o := frameOptions{lang: lang, theme: max(theme, 0), tab: tab, width: width, height: height, color: !*noColor, ascii: *ascii}
+	fmt.Println(o.frame())
+	return nil
+}
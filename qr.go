@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/skip2/go-qrcode"
+)
+
+// Modules of blank margin around a code; scanners need some, and two are
+// enough for a code shown on its own
+const qrQuietZone = 2
+
+// Something a QR code can be shown for
+type qrTarget struct {
+	label   string
+	content string // encoded text
+	caption string // shown under the code
+}
+
+// Codes of the QR view: the contact links, the projects' URLs and the
+// contact card
+func (m model) qrTargets() []qrTarget {
+	labels := []string{m.t("email"), m.t("linkedin"), m.t("github")}
+	var targets []qrTarget
+	for i, l := range contactLinks() {
+		targets = append(targets, qrTarget{labels[i], l.url, m.link(l.url, l.text)})
+	}
+	// The projects of the Projects tab, so the codes match what it links
+	projects, _ := tabIndex("projects")
+	for _, e := range m.resume()[projects].entries {
+		if e.url != "" {
+			targets = append(targets, qrTarget{e.title, e.url, m.link(e.url, e.url)})
+		}
+	}
+	return append(targets, qrTarget{m.t("qr_vcard"), vCard(owner, m.t("subtitle")), owner.Name})
+}
+
+// Opens the QR view on the focused link, or on the first code
+func (m *model) openQR() {
+	m.qr = true
+	m.qrCursor = 0
+	if f, ok := m.focusedLink(); ok {
+		for i, t := range m.qrTargets() {
+			if strings.EqualFold(t.content, f.url) {
+				m.qrCursor = i
+			}
+		}
+	}
+}
+
+func (m *model) updateQR(key string) {
+	n := len(m.qrTargets())
+	switch key {
+	case "left", "h", "N", "shift+tab":
+		m.qrCursor = (m.qrCursor + n - 1) % n
+	case "right", "l", "n", "tab":
+		m.qrCursor = (m.qrCursor + 1) % n
+	case "esc", "q", "c":
+		m.qr = false
+	}
+}
+
+// QR view popup: the selected code, sized to fit over the whole frame
+func (m model) qrView() string {
+	targets := m.qrTargets()
+	target := targets[m.qrCursor]
+	l := m.layout()
+
+	// The frame is 2 columns narrower and a line shorter than the window.
+	// The popup's border and padding take 4 columns, and its border, title,
+	// caption and hint 5 rows, plus a blank line above and below the code
+	// when there is room for them.
+	frameWidth, frameHeight := l.width-2, l.height-1
+	code, err := m.qrCode(target.content, frameWidth-4, frameHeight-5)
+	if err != nil {
+		code = m.styles.warning.Render(visualOrder(m.t("qr_too_small")))
+	}
+	if lipgloss.Height(code)+7 <= frameHeight {
+		code = "\n" + code + "\n"
+	}
+
+	title := fmt.Sprintf("%s (%d/%d)", visualOrder(target.label), m.qrCursor+1, len(targets))
+	width := max(lipgloss.Width(code), lipgloss.Width(title))
+	return m.renderer.NewStyle().
+		Border(m.styles.box.Border).
+		BorderForeground(m.styles.frame.GetForeground()).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			m.styles.title.Render(title),
+			code,
+			m.styles.content.Render(ansi.Truncate(target.caption, width, "…")),
+			m.styles.muted.Render(ansi.Truncate(visualOrder(m.t("qr_hint")), width, "…")),
+		))
+}
+
+// Draws text as a QR code no larger than width x height cells. Each cell
+// holds two modules, one above the other, with half blocks; ASCII terminals
+// get two #s per module instead. Modules are drawn in the terminal's own
+// colors: light ones in the foreground color on a dark background, dark
+// ones on a light background, so the code always reads dark on light.
+func (m model) qrCode(text string, width, height int) (string, error) {
+	q, err := qrcode.New(text, qrcode.Low)
+	if err != nil {
+		return "", err
+	}
+	q.DisableBorder = true
+	bitmap := q.Bitmap()
+
+	size := len(bitmap) + 2*qrQuietZone
+	cols, rows := size, (size+1)/2
+	if m.ascii {
+		cols, rows = 2*size, size
+	}
+	if cols > width || rows > height {
+		return "", fmt.Errorf("QR code of %dx%d cells doesn't fit %dx%d", cols, rows, width, height)
+	}
+
+	darkBackground := m.renderer.HasDarkBackground()
+	ink := func(x, y int) bool {
+		x, y = x-qrQuietZone, y-qrQuietZone
+		dark := y >= 0 && y < len(bitmap) && x >= 0 && x < len(bitmap) && bitmap[y][x]
+		return dark != darkBackground
+	}
+
+	var b strings.Builder
+	if m.ascii {
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				if ink(x, y) {
+					b.WriteString("##")
+				} else {
+					b.WriteString("  ")
+				}
+			}
+			b.WriteString("\n")
+		}
+		return strings.TrimSuffix(b.String(), "\n"), nil
+	}
+
+	for y := 0; y < size; y += 2 {
+		for x := 0; x < size; x++ {
+			switch top, bottom := ink(x, y), y+1 < size && ink(x, y+1); {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
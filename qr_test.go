@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// At 80x24 the links' codes fit over the frame; the contact card's, which
+// holds the whole vCard, needs a larger window
+func TestQRFits80x24(t *testing.T) {
+	for _, l := range languages {
+		m := initialModel(l.lang)
+		m.width, m.height = 80, 24
+		m.screen = PortfolioScreen
+		m.qr = true
+		targets := m.qrTargets()
+		for i, target := range targets[:len(targets)-1] {
+			m.qrCursor = i
+			view := m.View()
+			if strings.Contains(view, visualOrder(m.t("qr_too_small"))) {
+				t.Errorf("%s: the code of %s doesn't fit 80x24", l.lang, target.label)
+			}
+			if h := lipgloss.Height(view); h > m.height {
+				t.Errorf("%s: the view of %s is %d lines high", l.lang, target.label, h)
+			}
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Keys a tape can press by name, as in VHS; Alt+ goes before any of them
+var tapeKeys = map[string]tea.KeyType{
+	"Enter":     tea.KeyEnter,
+	"Tab":       tea.KeyTab,
+	"Shift+Tab": tea.KeyShiftTab,
+	"Space":     tea.KeySpace,
+	"Backspace": tea.KeyBackspace,
+	"Escape":    tea.KeyEscape,
+	"Up":        tea.KeyUp,
+	"Down":      tea.KeyDown,
+	"Left":      tea.KeyLeft,
+	"Right":     tea.KeyRight,
+	"Home":      tea.KeyHome,
+	"End":       tea.KeyEnd,
+	"PageUp":    tea.KeyPgUp,
+	"PageDown":  tea.KeyPgDown,
+	"Ctrl+C":    tea.KeyCtrlC,
+}
+
+// A step of a tape: keys pressed delay apart, a pause, or a resize
+type tapeStep struct {
+	keys          []tea.KeyMsg
+	delay         time.Duration
+	sleep         time.Duration
+	width, height int
+}
+
+// A demo script, in a subset of VHS's tape language:
+//
+//	Set Size 100x30     terminal size, 80x24 by default
+//	Set Lang fr         interface language, English by default
+//	Set Theme light     color theme, the default theme otherwise
+//	Set TypingSpeed 80ms
+//	Type "L"            types text, a key per character
+//	Down@200ms 3        presses a key 3 times, 200ms apart
+//	Sleep 2s            waits, letting animations and toasts run
+//	Resize 120x40       resizes the terminal
+type tape struct {
+	lang          Lang
+	theme         int
+	width, height int
+	typingSpeed   time.Duration
+	steps         []tapeStep
+}
+
+// A duration of a tape: 500ms, 2s, or a number of seconds
+func tapeDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// Reads a tape, reporting problems with their line number
+func parseTape(r io.Reader, name string) (tape, error) {
+	t := tape{lang: EN, width: 80, height: 24, typingSpeed: 50 * time.Millisecond}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := t.parseLine(line); err != nil {
+			return t, fmt.Errorf("%s:%d: %w", name, n, err)
+		}
+	}
+	return t, scanner.Err()
+}
+
+func (t *tape) parseLine(line string) error {
+	command, rest, _ := strings.Cut(line, " ")
+	rest = strings.TrimSpace(rest)
+	command, speed, hasSpeed := strings.Cut(command, "@")
+	step := tapeStep{delay: t.typingSpeed}
+	if hasSpeed {
+		d, err := tapeDuration(speed)
+		if err != nil {
+			return err
+		}
+		step.delay = d
+	}
+
+	switch command {
+	case "Set":
+		setting, value, _ := strings.Cut(rest, " ")
+		return t.set(setting, strings.TrimSpace(value))
+	case "Sleep":
+		d, err := tapeDuration(rest)
+		if err != nil {
+			return err
+		}
+		step.sleep = d
+	case "Resize":
+		width, height, err := parseSize(rest)
+		if err != nil {
+			return err
+		}
+		step.width, step.height = width, height
+	case "Type":
+		text, err := strconv.Unquote(rest)
+		if err != nil {
+			return fmt.Errorf("Type takes a quoted string, not %s", rest)
+		}
+		for _, r := range text {
+			if r == ' ' {
+				step.keys = append(step.keys, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}})
+			} else {
+				step.keys = append(step.keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+		}
+	default:
+		name, alt := strings.CutPrefix(command, "Alt+")
+		key, ok := tapeKeys[name]
+		if !ok {
+			return fmt.Errorf("unknown command %q", command)
+		}
+		count := 1
+		if rest != "" {
+			var err error
+			if count, err = strconv.Atoi(rest); err != nil || count < 1 {
+				return fmt.Errorf("invalid count %q", rest)
+			}
+		}
+		for range count {
+			step.keys = append(step.keys, tea.KeyMsg{Type: key, Alt: alt})
+		}
+	}
+	t.steps = append(t.steps, step)
+	return nil
+}
+
+func (t *tape) set(setting, value string) error {
+	if value == "" {
+		return fmt.Errorf("Set %s takes a value", setting)
+	}
+	var err error
+	switch setting {
+	case "Size":
+		if len(t.steps) > 0 {
+			return fmt.Errorf("Set Size comes before the first command; use Resize after it")
+		}
+		t.width, t.height, err = parseSize(value)
+	case "Lang":
+		t.lang, err = startLang(value)
+	case "Theme":
+		t.theme, err = startTheme(value)
+	case "TypingSpeed":
+		t.typingSpeed, err = tapeDuration(value)
+	default:
+		err = fmt.Errorf("unknown setting %q (available: Size, Lang, Theme, TypingSpeed)", setting)
+	}
+	return err
+}
+
+// A message the model scheduled with after, due on the recorder's clock
+type timerMsg struct {
+	d  time.Duration
+	fn func(time.Time) tea.Msg
+}
+
+type tapeTimer struct {
+	at time.Duration
+	fn func(time.Time) tea.Msg
+}
+
+// Drives the model through a tape on a clock of its own, so a recording
+// takes no longer to make than to render, and keeps every frame that
+// changes as an asciicast event
+type recorder struct {
+	m      model
+	now    time.Duration
+	timers []tapeTimer
+	quit   bool
+	last   string
+	events bytes.Buffer
+}
+
+func (r *recorder) event(kind, data string) {
+	e, _ := json.Marshal([]any{json.Number(strconv.FormatFloat(r.now.Seconds(), 'f', 6, 64)), kind, data})
+	r.events.Write(append(e, '\n'))
+}
+
+// Writes the frame when it changed, redrawing the whole screen
+func (r *recorder) render() {
+	frame := r.m.View()
+	if r.quit || frame == r.last {
+		return
+	}
+	data := "\x1b[H" + strings.ReplaceAll(frame, "\n", "\x1b[K\r\n") + "\x1b[K\x1b[J"
+	if r.last == "" {
+		data = "\x1b[?25l\x1b[2J" + data
+	}
+	r.last = frame
+	r.event("o", data)
+}
+
+func (r *recorder) update(msg tea.Msg) {
+	next, cmd := r.m.Update(msg)
+	r.m = next.(model)
+	r.run(cmd)
+	r.render()
+}
+
+// Runs a command of the model: timers are queued on the clock, and other
+// messages go straight back to the model
+func (r *recorder) run(cmd tea.Cmd) {
+	if cmd == nil || r.quit {
+		return
+	}
+	switch msg := cmd().(type) {
+	case nil:
+	case timerMsg:
+		r.timers = append(r.timers, tapeTimer{r.now + msg.d, msg.fn})
+	case tea.BatchMsg:
+		for _, c := range msg {
+			r.run(c)
+		}
+	case tea.QuitMsg:
+		r.quit = true
+	default:
+		r.update(msg)
+	}
+}
+
+// Moves the clock on by d, firing the timers due on the way in order
+func (r *recorder) advance(d time.Duration) {
+	end := r.now + d
+	for !r.quit {
+		next := -1
+		for i, t := range r.timers {
+			if t.at <= end && (next < 0 || t.at < r.timers[next].at) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		t := r.timers[next]
+		r.timers = append(r.timers[:next], r.timers[next+1:]...)
+		r.now = t.at
+		r.update(t.fn(time.Time{}.Add(t.at)))
+	}
+	r.now = end
+}
+
+// Plays a tape from the welcome screen and writes it as an asciicast v2
+// recording, stopping early if the tape quits
+func recordTape(w io.Writer, t tape) error {
+	restore := after
+	defer func() { after = restore }()
+	after = func(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+		return func() tea.Msg { return timerMsg{d, fn} }
+	}
+
+	o := frameOptions{lang: t.lang, theme: max(t.theme, 0), width: t.width, height: t.height, color: true, welcome: true}
+	r := &recorder{m: o.model()}
+	r.render()
+	r.run(r.m.Init())
+	for _, step := range t.steps {
+		if r.quit {
+			break
+		}
+		switch {
+		case step.width > 0:
+			r.event("r", fmt.Sprintf("%dx%d", step.width, step.height))
+			r.update(tea.WindowSizeMsg{Width: step.width, Height: step.height})
+		case step.sleep > 0:
+			r.advance(step.sleep)
+		}
+		for _, key := range step.keys {
+			r.update(key)
+			r.advance(step.delay)
+		}
+	}
+	// Holds the last frame until the end of the tape
+	r.event("o", "")
+
+	header, err := json.Marshal(map[string]any{
+		"version": 2,
+		"width":   t.width,
+		"height":  t.height,
+		"title":   owner.Name + " — termfolio",
+		"env":     map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(header, '\n')); err != nil {
+		return err
+	}
+	_, err = r.events.WriteTo(w)
+	return err
+}
+
+// termfolio record [--themes <dir>] <script.tape> <out.cast>
+func recordCommand(args []string) error {
+	fs := flag.NewFlagSet("record", flag.ContinueOnError)
+	themesDir := fs.String("themes", themeDir(), "Directory of user themes (*.toml)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: termfolio record <script.tape> <out.cast>")
+	}
+	for _, err := range loadThemes(*themesDir) {
+		log.Print(err)
+	}
+
+	script, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer script.Close()
+	t, err := parseTape(script, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if err := recordTape(f, t); err != nil {
+		f.Close()
+		os.Remove(fs.Arg(1))
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTapeDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "500ms", want: 500 * time.Millisecond},
+		{in: "2s", want: 2 * time.Second},
+		{in: "1m30s", want: 90 * time.Second},
+		{in: "2", want: 2 * time.Second},
+		{in: "0.25", want: 250 * time.Millisecond},
+		{in: "0", want: 0},
+		{in: "-1s", wantErr: true},
+		{in: "-2", wantErr: true},
+		{in: "soon", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tapeDuration(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("tapeDuration(%q) = %v, %v, want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseTape(t *testing.T) {
+	key := func(k tea.KeyType) tea.KeyMsg { return tea.KeyMsg{Type: k} }
+	runes := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	const typing = 50 * time.Millisecond
+	tests := []struct {
+		name string
+		src  string
+		want []tapeStep
+	}{
+		{"sleep", "Sleep 2s", []tapeStep{{delay: typing, sleep: 2 * time.Second}}},
+		{"sleep in seconds", "Sleep 1.5", []tapeStep{{delay: typing, sleep: 1500 * time.Millisecond}}},
+		{"key", "Enter", []tapeStep{{keys: []tea.KeyMsg{key(tea.KeyEnter)}, delay: typing}}},
+		{"key with a delay and count", "Down@200ms 2", []tapeStep{{keys: []tea.KeyMsg{key(tea.KeyDown), key(tea.KeyDown)}, delay: 200 * time.Millisecond}}},
+		{"alt key", "Alt+Left", []tapeStep{{keys: []tea.KeyMsg{{Type: tea.KeyLeft, Alt: true}}, delay: typing}}},
+		{"type", `Type "y n"`, []tapeStep{{keys: []tea.KeyMsg{runes('y'), {Type: tea.KeySpace, Runes: []rune{' '}}, runes('n')}, delay: typing}}},
+		{"type with a delay", `Type@1s "L"`, []tapeStep{{keys: []tea.KeyMsg{runes('L')}, delay: time.Second}}},
+		{"typing speed", "Set TypingSpeed 80ms\nTab", []tapeStep{{keys: []tea.KeyMsg{key(tea.KeyTab)}, delay: 80 * time.Millisecond}}},
+		{"resize", "Resize 120x40", []tapeStep{{delay: typing, width: 120, height: 40}}},
+		{"comments and blank lines", "# intro\n\nSleep 500ms", []tapeStep{{delay: typing, sleep: 500 * time.Millisecond}}},
+	}
+	for _, tt := range tests {
+		got, err := parseTape(strings.NewReader(tt.src), "test.tape")
+		if err != nil {
+			t.Errorf("%s: parseTape(%q): %v", tt.name, tt.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(got.steps, tt.want) {
+			t.Errorf("%s: parseTape(%q) steps = %+v, want %+v", tt.name, tt.src, got.steps, tt.want)
+		}
+	}
+}
+
+func TestParseTapeErrors(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"Sleep soon", `test.tape:1: invalid duration "soon"`},
+		{"Sleep -1s", `test.tape:1: invalid duration "-1s"`},
+		{"Enter\nDown@fast", `test.tape:2: invalid duration "fast"`},
+		{"Down 0", `test.tape:1: invalid count "0"`},
+		{"Jump", `test.tape:1: unknown command "Jump"`},
+		{"Type L", "test.tape:1: Type takes a quoted string, not L"},
+		{"Set TypingSpeed", "test.tape:1: Set TypingSpeed takes a value"},
+		{"Enter\nSet Size 100x30", "test.tape:2: Set Size comes before the first command; use Resize after it"},
+	}
+	for _, tt := range tests {
+		_, err := parseTape(strings.NewReader(tt.src), "test.tape")
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("parseTape(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
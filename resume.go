@@ -0,0 +1,192 @@
+package main
+
+import "strings"
+
+// The resume as the tabs show it, in the model's language and without
+// styles, so the exports lay out the same content as the terminal
+type resumeSection struct {
+	title   string
+	entries []resumeEntry
+	compact bool // one line per entry, as the skill groups
+}
+
+// One job, degree, project or skill group
+type resumeEntry struct {
+	name   string // short name for the breadcrumb
+	org    string // company of a job
+	title  string
+	url    string
+	blocks []resumeBlock
+}
+
+type blockKind int
+
+const (
+	blockText blockKind = iota // paragraph, after an optional label
+	blockMeta                  // dates, places and status, in the muted style
+	blockTags                  // technologies, after an optional label
+)
+
+// Lines of an entry. Text keeps the catalog's line breaks, which fit the
+// right panel; exports that reflow text join them with spaces.
+type resumeBlock struct {
+	kind      blockKind
+	label     string
+	lines     []string
+	tags      []string
+	highlight string // set off after the last line of a meta block
+}
+
+// Text of a block as one paragraph. The highlight, a remark about the
+// terminal itself, is left out along with the dash leading to it.
+func (b resumeBlock) text() string {
+	if b.kind == blockTags {
+		return strings.Join(b.tags, ", ")
+	}
+	text := strings.Join(b.lines, " ")
+	if b.highlight != "" {
+		text = strings.TrimRight(text, " —")
+	}
+	return text
+}
+
+func textBlock(label string, lines ...string) resumeBlock {
+	return resumeBlock{kind: blockText, label: label, lines: lines}
+}
+
+func metaBlock(line string) resumeBlock {
+	return resumeBlock{kind: blockMeta, lines: []string{line}}
+}
+
+func tagsBlock(label string, tags ...string) resumeBlock {
+	return resumeBlock{kind: blockTags, label: label, tags: tags}
+}
+
+// Biography paragraphs of the left panel
+func (m model) bio() [][]string {
+	return [][]string{
+		{m.t("about_1"), m.t("about_2"), m.t("about_3")},
+		{m.t("about_4"), m.t("about_5"), m.t("about_6")},
+	}
+}
+
+// Contact details under the name of a printed resume: email, phone,
+// location and profiles, linked except for the location
+func (m model) contactDetails() []focusLink {
+	contact := contactLinks()
+	details := []focusLink{contact[0]}
+	if owner.Phone != "" {
+		details = append(details, focusLink{"tel:" + strings.ReplaceAll(owner.Phone, " ", ""), owner.Phone})
+	}
+	details = append(details, focusLink{"", m.t("home_location")})
+	return append(details, contact[1:]...)
+}
+
+// Sections of the resume, one per tab
+func (m model) resume() []resumeSection {
+	return []resumeSection{
+		{
+			title: m.t("exp_title"),
+			entries: []resumeEntry{
+				{"Gatewatcher", "Gatewatcher", m.t("gw_title"), "", []resumeBlock{
+					metaBlock(m.t("gw_role")),
+					textBlock(m.t("mission"), m.t("gw_mission_1"), m.t("gw_mission_2"), m.t("gw_mission_3"), m.t("gw_mission_4"), m.t("gw_mission_5")),
+					tagsBlock(m.t("stack"), "Python", "Ansible", "Docker", "Bash", "Linux", "CI/CD"),
+					textBlock(m.t("challenge"), m.t("gw_challenge")),
+					textBlock(m.t("feedback"), m.t("gw_feedback_1"), m.t("gw_feedback_2")),
+				}},
+				{"Etifak", "Etifak", m.t("etifak_title"), "", []resumeBlock{
+					metaBlock(m.t("etifak_role")),
+					textBlock(m.t("mission"), m.t("etifak_mission_1"), m.t("etifak_mission_2"), m.t("etifak_mission_3")),
+					tagsBlock(m.t("stack"), "Python", "Django", "PostgreSQL", "Docker", "AWS EC2"),
+					textBlock(m.t("challenge"), m.t("etifak_challenge")),
+					textBlock(m.t("feedback"), m.t("etifak_feedback")),
+				}},
+				{"Suez Digital Solutions", "Suez Digital Solutions", m.t("suez_title"), "", []resumeBlock{
+					metaBlock(m.t("suez_role")),
+					textBlock(m.t("mission"), m.t("suez_mission_1"), m.t("suez_mission_2")),
+					tagsBlock(m.t("stack"), "Python", "Streamlit", "Pandas", "asyncio", "Azure DevOps"),
+					textBlock(m.t("feedback"), m.t("suez_feedback")),
+				}},
+			},
+		},
+		{
+			title: m.t("edu_title"),
+			entries: []resumeEntry{
+				{"ENSISA", "", m.t("ensisa_degree"), "", []resumeBlock{
+					textBlock("", m.t("ensisa_school")),
+					metaBlock(m.t("ensisa_period") + " | " + m.t("ensisa_loc")),
+					textBlock("", m.t("ensisa_desc_1"), m.t("ensisa_desc_2"), m.t("ensisa_desc_3")),
+				}},
+				{m.t("cpge_school"), "", m.t("cpge_degree"), "", []resumeBlock{
+					textBlock("", m.t("cpge_school")),
+					metaBlock(m.t("cpge_period") + " | " + m.t("cpge_loc")),
+					textBlock("", m.t("cpge_desc_1"), m.t("cpge_desc_2"), m.t("cpge_desc_3")),
+				}},
+				{m.t("bac_school"), "", m.t("bac_degree"), "", []resumeBlock{
+					textBlock("", m.t("bac_school")),
+					metaBlock(m.t("bac_period") + " | " + m.t("bac_loc")),
+					textBlock("", m.t("bac_desc_1"), m.t("bac_desc_2")),
+				}},
+			},
+		},
+		{
+			title: m.t("proj_title"),
+			entries: []resumeEntry{
+				{"SimplyLovelySetups.com", "", "SimplyLovelySetups.com", "https://simplylovelysetups.com", []resumeBlock{
+					metaBlock(m.t("sls_period")),
+					textBlock("", m.t("sls_desc_1"), m.t("sls_desc_2"), m.t("sls_desc_3")),
+					tagsBlock(m.t("stack"), "FastAPI", "SQLAlchemy", "MongoDB", "NextJS", "Docker"),
+					metaBlock(m.t("sls_status")),
+				}},
+				{"termfolio.dev", "", "termfolio.dev", "https://termfolio.dev", []resumeBlock{
+					metaBlock(m.t("termfolio_period")),
+					textBlock("", m.t("termfolio_desc_1"), m.t("termfolio_desc_2"), m.t("termfolio_desc_3")),
+					tagsBlock(m.t("stack"), "Go", "Bubble Tea", "Lipgloss", "SSH (Wish)"),
+					{kind: blockMeta, lines: []string{m.t("termfolio_status")}, highlight: m.t("termfolio_highlight")},
+				}},
+				{"MealPass", "", "MealPass", "", []resumeBlock{
+					metaBlock(m.t("mealpass_period")),
+					textBlock("", m.t("mealpass_desc_1"), m.t("mealpass_desc_2"), m.t("mealpass_desc_3")),
+					tagsBlock(m.t("stack"), "Django", "PostgreSQL", "Flutter", "Dart", "Docker"),
+					metaBlock(m.t("mealpass_status")),
+				}},
+				{"NeoShape", "", "NeoShape", "", []resumeBlock{
+					metaBlock("2024"),
+					textBlock("", m.t("vectorart_desc_1"), m.t("vectorart_desc_2")),
+					tagsBlock(m.t("stack"), "Java", "JavaFX"),
+					metaBlock(m.t("vectorart_status")),
+				}},
+				{"Nexus", "", "Nexus", "", []resumeBlock{
+					metaBlock("2024"),
+					textBlock("", m.t("discordclone_desc_1"), m.t("discordclone_desc_2")),
+					tagsBlock(m.t("stack"), "Python", "Django", "WebSocket", "HTML/CSS"),
+					metaBlock(m.t("discordclone_status")),
+				}},
+				{"NewMoodle", "", "NewMoodle", "", []resumeBlock{
+					metaBlock("2024"),
+					textBlock("", m.t("schoolmgmt_desc_1"), m.t("schoolmgmt_desc_2")),
+					tagsBlock(m.t("stack"), "Python", "Django", "PostgreSQL", "HTML/CSS"),
+					metaBlock(m.t("schoolmgmt_status")),
+				}},
+			},
+		},
+		{
+			title:   m.t("skills_title"),
+			compact: true,
+			entries: []resumeEntry{
+				skillGroup(m.t("skills_languages"), "Python", "Go", "Rust", "TypeScript", "JavaScript", "Java", "C", "F#"),
+				skillGroup(m.t("skills_backend"), "Django", "DRF", "FastAPI", "PostgreSQL", "MongoDB", "SQLAlchemy"),
+				skillGroup(m.t("skills_frontend"), "React.js", "NextJS", "Flutter", "HTML/CSS"),
+				skillGroup(m.t("skills_devops"), "Docker", "AWS", "Azure", "CI/CD", "Linux", "Ansible", "Bash"),
+				skillGroup(m.t("skills_security"), "Suricata", m.t("skills_network_analysis"), "Selenium", "Beautiful Soup"),
+				skillGroup(m.t("skills_data"), "Pandas", "Streamlit", "Azure ML"),
+				skillGroup(m.t("skills_tools"), "Git", "Scrum/Agile", m.t("skills_code_review"), "REST APIs"),
+			},
+		},
+	}
+}
+
+func skillGroup(name string, skills ...string) resumeEntry {
+	return resumeEntry{name: name, title: name, blocks: []resumeBlock{tagsBlock("", skills...)}}
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Review mode pages: tabs side by side, or one catalog key at a time
+type reviewPage int
+
+const (
+	reviewSections reviewPage = iota
+	reviewKeys
+)
+
+// Translator review: a translation next to its base language, both at the
+// same width, with the entries and keys that diverge flagged
+type reviewModel struct {
+	width  int
+	height int
+	page   reviewPage
+
+	// Models rendering each side
+	base   model
+	target model
+
+	// Sections page
+	section int
+	scroll  int
+
+	// Keys page: every key of both catalogs, in base catalog order
+	keys      []string
+	key       int
+	baseSrc   map[string]string
+	targetSrc map[string]string
+}
+
+// Rows of the review above and below the columns: title, status, column
+// headings, separator and footer
+const reviewChrome = 5
+
+func newReviewModel(base, target Lang) reviewModel {
+	baseSrc, keys, _ := catalogSource(base)
+	targetSrc, targetKeys, _ := catalogSource(target)
+	for _, key := range targetKeys {
+		if _, ok := baseSrc[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return reviewModel{
+		width:     80,
+		height:    24,
+		base:      initialModel(base),
+		target:    initialModel(target),
+		keys:      keys,
+		baseSrc:   baseSrc,
+		targetSrc: targetSrc,
+	}
+}
+
+func (r reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (r reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		r.width = msg.Width
+		r.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return r, tea.Quit
+		case "tab":
+			r.page = 1 - r.page
+			return r, nil
+		}
+		if r.page == reviewSections {
+			r.updateSections(msg.String())
+		} else {
+			r.updateKeys(msg.String())
+		}
+	}
+	return r, nil
+}
+
+func (r *reviewModel) updateSections(key string) {
+	rows, starts := r.sectionRows()
+	switch key {
+	case "left", "h":
+		r.section = (r.section + len(r.base.tabTitles()) - 1) % len(r.base.tabTitles())
+		r.scroll = 0
+	case "right", "l":
+		r.section = (r.section + 1) % len(r.base.tabTitles())
+		r.scroll = 0
+	case "up", "k":
+		r.scroll = max(r.scroll-1, 0)
+	case "down", "j":
+		r.scroll = min(r.scroll+1, r.maxScroll(rows, starts))
+	case "n", "N":
+		// Next or previous diverging entry
+		reasons := r.divergences(r.section)
+		current := entryAt(starts, r.scroll)
+		for i := range reasons {
+			step := i + 1
+			if key == "N" {
+				step = -step
+			}
+			e := ((current+step)%len(reasons) + len(reasons)) % len(reasons)
+			if reasons[e] != "" {
+				r.scroll = min(starts[e], r.maxScroll(rows, starts))
+				break
+			}
+		}
+	}
+}
+
+func (r *reviewModel) updateKeys(key string) {
+	switch key {
+	case "up", "k":
+		r.key = max(r.key-1, 0)
+	case "down", "j":
+		r.key = min(r.key+1, len(r.keys)-1)
+	case "n", "N":
+		// Next or previous key with a problem
+		for i := range r.keys {
+			step := i + 1
+			if key == "N" {
+				step = -step
+			}
+			k := ((r.key+step)%len(r.keys) + len(r.keys)) % len(r.keys)
+			if r.keyProblem(r.keys[k]) != "" {
+				r.key = k
+				break
+			}
+		}
+	}
+}
+
+// Width of each side; a row is a marker, the base side, a separator, a
+// marker and the translated side
+func (r reviewModel) columnWidth() int {
+	return (max(r.width, minWidth) - 3) / 2
+}
+
+func (r reviewModel) bodyHeight() int {
+	return max(r.height, minHeight) - reviewChrome
+}
+
+// Last scroll offset of the sections page: the end of the rows, or the start
+// of the last entry so that any entry can be brought to the top
+func (r reviewModel) maxScroll(rows []string, starts []int) int {
+	last := 0
+	if len(starts) > 0 {
+		last = starts[len(starts)-1]
+	}
+	return max(len(rows)-r.bodyHeight(), last, 0)
+}
+
+// Why each entry of a tab diverges between the two languages, or "" when
+// it doesn't: a different number of lines at the column width, or keys one
+// of the catalogs lacks
+func (r reviewModel) divergences(section int) []string {
+	w := r.columnWidth()
+	baseLines, baseStarts := renderPanel(r.base.tabContent(section), w)
+	targetLines, targetStarts := renderPanel(r.target.tabContent(section), w)
+	baseLens := spanLengths(baseStarts, len(baseLines))
+	targetLens := spanLengths(targetStarts, len(targetLines))
+
+	var reasons []string
+	for i, keys := range r.entryKeys(section) {
+		var problems []string
+		if i >= len(targetLens) {
+			problems = append(problems, "missing in "+string(r.target.lang))
+		} else if baseLens[i] != targetLens[i] {
+			problems = append(problems, fmt.Sprintf("%d lines vs %d", baseLens[i], targetLens[i]))
+		}
+		for _, key := range keys {
+			if missing := r.keyMissing(key); missing != "" {
+				problems = append(problems, key+" "+missing)
+			}
+		}
+		reasons = append(reasons, strings.Join(problems, "; "))
+	}
+	return reasons
+}
+
+// Keys shown by each entry of a tab: the tab is rendered while recording
+// the keys it looks up, and each key goes to the entries containing its
+// base language text
+func (r reviewModel) entryKeys(section int) [][]string {
+	m := r.base
+	m.usedKeys = map[string]bool{}
+	c := m.tabContent(section)
+	used := slices.Sorted(maps.Keys(m.usedKeys))
+
+	entries := make([][]string, len(c.entries))
+	for i, e := range c.entries {
+		text := plainWords(strings.Join(e.lines, " "))
+		for _, key := range used {
+			if msg := plainWords(m.t(key)); msg != "" && strings.Contains(text, msg) {
+				entries[i] = append(entries[i], key)
+			}
+		}
+	}
+	return entries
+}
+
+// Which catalog lacks a key, if any
+func (r reviewModel) keyMissing(key string) string {
+	if _, ok := r.baseSrc[key]; !ok {
+		return "missing in " + string(r.base.lang)
+	}
+	if _, ok := r.targetSrc[key]; !ok {
+		return "missing in " + string(r.target.lang)
+	}
+	return ""
+}
+
+// What is wrong with a key: missing from a catalog, or wrapping onto a
+// different number of lines than its base text at the column width
+func (r reviewModel) keyProblem(key string) string {
+	if missing := r.keyMissing(key); missing != "" {
+		return missing
+	}
+
+	wrap := lipgloss.NewStyle().Width(r.columnWidth() - 1)
+	baseRows := lipgloss.Height(wrap.Render(r.base.t(key)))
+	targetRows := lipgloss.Height(wrap.Render(r.target.t(key)))
+	if baseRows != targetRows {
+		return fmt.Sprintf("wraps on %d lines vs %d", baseRows, targetRows)
+	}
+	return ""
+}
+
+// Lines taken by each entry, from the line each one starts on
+func spanLengths(starts []int, total int) []int {
+	lens := make([]int, len(starts))
+	for i, start := range starts {
+		end := total
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		lens[i] = end - start
+	}
+	return lens
+}
+
+// Text without styling, links or repeated spaces
+func plainWords(s string) string {
+	return strings.Join(strings.Fields(ansi.Strip(s)), " ")
+}
+
+// Rows of the sections page with both sides aligned entry by entry, and the
+// row on which each entry starts
+func (r reviewModel) sectionRows() ([]string, []int) {
+	st := r.base.styles
+	w := r.columnWidth()
+	baseLines, baseStarts := renderPanel(r.base.tabContent(r.section), w)
+	targetLines, targetStarts := renderPanel(r.target.tabContent(r.section), w)
+	reasons := r.divergences(r.section)
+
+	// Spans of the header and of each entry on both sides
+	baseSpans := splitSpans(baseLines, baseStarts)
+	targetSpans := splitSpans(targetLines, targetStarts)
+
+	var rows []string
+	var starts []int
+	for i := range baseSpans {
+		marker := " "
+		if i > 0 {
+			starts = append(starts, len(rows))
+			if i-1 < len(reasons) && reasons[i-1] != "" {
+				marker = st.warning.Render("▌")
+			}
+		}
+		var target []string
+		if i < len(targetSpans) {
+			target = targetSpans[i]
+		}
+		for j := 0; j < max(len(baseSpans[i]), len(target)); j++ {
+			left, right := "", ""
+			if j < len(baseSpans[i]) {
+				left = baseSpans[i][j]
+			}
+			if j < len(target) {
+				right = target[j]
+			}
+			rows = append(rows, marker+r.base.panelLine(left, w)+st.frame.Render(st.box.Left)+marker+r.target.panelLine(right, w))
+		}
+	}
+	return rows, starts
+}
+
+// Cuts panel lines into the header and one span per entry; a panel without
+// entries is all header
+func splitSpans(lines []string, starts []int) [][]string {
+	if len(starts) == 0 {
+		return [][]string{lines}
+	}
+	spans := [][]string{lines[:starts[0]]}
+	for i, start := range starts {
+		end := len(lines)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		spans = append(spans, lines[start:end])
+	}
+	return spans
+}
+
+func (r reviewModel) View() string {
+	st := r.base.styles
+	width := r.columnWidth()*2 + 3
+	baseName := languages[max(langIndex(r.base.lang), 0)].name
+	targetName := languages[max(langIndex(r.target.lang), 0)].name
+
+	var status string
+	var body []string
+	var help string
+	if r.page == reviewSections {
+		rows, starts := r.sectionRows()
+		reasons := r.divergences(r.section)
+		entry := entryAt(starts, r.scroll)
+
+		diverging := 0
+		for _, reason := range reasons {
+			if reason != "" {
+				diverging++
+			}
+		}
+		status = fmt.Sprintf("%s (%d/%d) • %d of %d entries diverge",
+			r.base.tabTitles()[r.section], r.section+1, len(r.base.tabTitles()), diverging, len(reasons))
+		if entry < len(reasons) && reasons[entry] != "" {
+			title := r.base.tabContent(r.section).entries[entry].title
+			status += " • " + title + ": " + st.warning.Render(reasons[entry])
+		}
+
+		end := min(r.scroll+r.bodyHeight(), len(rows))
+		body = rows[r.scroll:end]
+		help = "←→: Section • ↑↓: Scroll • n/N: Next/previous divergence • Tab: Keys • q: Quit"
+	} else {
+		key := r.keys[r.key]
+		status = fmt.Sprintf("Key %d/%d • %s", r.key+1, len(r.keys), st.title.Render(key))
+		if problem := r.keyProblem(key); problem != "" {
+			status += " • " + st.warning.Render(problem)
+		} else if r.baseSrc[key] == r.targetSrc[key] {
+			status += " • " + st.muted.Render("same text in both")
+		}
+
+		// Sources, then the text they show, level on both sides
+		baseSource, baseShown := r.keyColumn(r.base, r.baseSrc, key)
+		targetSource, targetShown := r.keyColumn(r.target, r.targetSrc, key)
+		for _, part := range [][2][]string{{baseSource, targetSource}, {baseShown, targetShown}} {
+			for i := 0; i < max(len(part[0]), len(part[1])); i++ {
+				left, right := "", ""
+				if i < len(part[0]) {
+					left = part[0][i]
+				}
+				if i < len(part[1]) {
+					right = part[1][i]
+				}
+				body = append(body, " "+r.base.panelLine(left, r.columnWidth())+st.frame.Render(st.box.Left)+" "+r.target.panelLine(right, r.columnWidth()))
+			}
+		}
+		help = "↑↓: Key • n/N: Next/previous problem • Tab: Sections • q: Quit"
+	}
+
+	for len(body) < r.bodyHeight() {
+		body = append(body, " "+repeatString(" ", r.columnWidth())+st.frame.Render(st.box.Left))
+	}
+
+	headings := " " + r.base.panelLine(st.title.Render(" "+baseName), r.columnWidth()) +
+		st.frame.Render(st.box.Left) + " " + r.target.panelLine(st.title.Render(" "+visualOrder(targetName)), r.columnWidth())
+
+	lines := slices.Concat(
+		[]string{
+			st.title.Render(fmt.Sprintf(" Review: %s → %s", baseName, visualOrder(targetName))),
+			" " + ansi.Truncate(status, width-1, "…"),
+			headings,
+			st.frame.Render(repeatString(st.box.Top, r.columnWidth()+1) + st.box.Middle + repeatString(st.box.Top, r.columnWidth()+1)),
+		},
+		body,
+		[]string{st.muted.Render(" " + ansi.Truncate(help, width-1, "…"))},
+	)
+	view := strings.Join(lines, "\n")
+	if r.target.rtl() || r.base.rtl() {
+		view = enableExplicitBidi + view
+	}
+	return view
+}
+
+// One side of the keys page: the message source, and the text it shows
+func (r reviewModel) keyColumn(m model, sources map[string]string, key string) (source, shown []string) {
+	st := r.base.styles
+	src, ok := sources[key]
+	if !ok {
+		return []string{st.warning.Render(" missing")}, nil
+	}
+
+	wrap := lipgloss.NewStyle().PaddingLeft(1).Width(r.columnWidth())
+	source = append([]string{st.muted.Render(" Source")}, splitLines(wrap.Render(st.content.Render(src)))...)
+	shown = append([]string{"", st.muted.Render(" Shown")}, splitLines(wrap.Render(st.content.Render(m.t(key))))...)
+	return source, shown
+}
+
+// termfolio i18n review <lang> [<base lang>]: opens the review of a
+// translation against English or another base language
+func runReview(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: termfolio i18n review <lang> [<base lang>]")
+	}
+	target, ok := parseLocale(args[0])
+	if !ok {
+		return fmt.Errorf("unsupported language %q (available: %s)", args[0], strings.Join(langCodes(), ", "))
+	}
+	base := EN
+	if len(args) == 2 {
+		if base, ok = parseLocale(args[1]); !ok {
+			return fmt.Errorf("unsupported language %q (available: %s)", args[1], strings.Join(langCodes(), ", "))
+		}
+	}
+
+	p := tea.NewProgram(newReviewModel(base, target), tea.WithAltScreen())
+	_, err := p.Run()
+	fmt.Print(resetExplicitBidi)
+	return err
+}
@@ -0,0 +1,484 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/go-fonts/dejavu/dejavusansmono"
+	"github.com/go-fonts/dejavu/dejavusansmonobold"
+	"github.com/go-fonts/dejavu/dejavusansmonoboldoblique"
+	"github.com/go-fonts/dejavu/dejavusansmonooblique"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// Font size of screenshots in SVG pixels. PNGs are drawn screenshotScale
+// times larger, to stay sharp on high-density screens.
+const (
+	screenshotFontSize = 16
+	screenshotScale    = 2
+)
+
+// The terminal font of screenshots, regular, bold, oblique and bold oblique:
+// DejaVu Sans Mono has the box-drawing characters of every border and the
+// joined forms of Arabic letters
+var screenshotFonts = sync.OnceValue(func() [4]*sfnt.Font {
+	var fonts [4]*sfnt.Font
+	for i, ttf := range [][]byte{dejavusansmono.TTF, dejavusansmonobold.TTF, dejavusansmonooblique.TTF, dejavusansmonoboldoblique.TTF} {
+		f, err := sfnt.Parse(ttf)
+		if err != nil {
+			panic(err)
+		}
+		fonts[i] = f
+	}
+	return fonts
+})
+
+// A theme color, "#rrggbb" or an ANSI color number, as RGB
+func paletteColor(color string) colorful.Color {
+	return termenv.ConvertToRGB(termenv.TrueColor.Color(color))
+}
+
+// Style of the SGR sequences active on a cell
+type sgrStyle struct {
+	fg, bg                                  colorful.Color
+	bold, italic, faint, underline, reverse bool
+}
+
+// Reads SGR sequences such as "\x1b[1m\x1b[38;2;1;116;223m" over the
+// default colors of the screen
+func parseSGR(sgr string, fg, bg colorful.Color) sgrStyle {
+	s := sgrStyle{fg: fg, bg: bg}
+	for _, seq := range strings.Split(sgr, "\x1b[") {
+		params := strings.Split(strings.TrimSuffix(seq, "m"), ";")
+		for i := 0; i < len(params); i++ {
+			n, err := strconv.Atoi(params[i])
+			if err != nil && params[i] != "" {
+				continue
+			}
+			switch {
+			case n == 0:
+				s = sgrStyle{fg: fg, bg: bg}
+			case n == 1:
+				s.bold = true
+			case n == 2:
+				s.faint = true
+			case n == 3:
+				s.italic = true
+			case n == 4:
+				s.underline = true
+			case n == 7:
+				s.reverse = true
+			case n == 22:
+				s.bold, s.faint = false, false
+			case n == 23:
+				s.italic = false
+			case n == 24:
+				s.underline = false
+			case n == 27:
+				s.reverse = false
+			case n >= 30 && n <= 37, n >= 90 && n <= 97:
+				s.fg = ansiColor(n%10 + 8*(n/90))
+			case n >= 40 && n <= 47, n >= 100 && n <= 107:
+				s.bg = ansiColor(n%10 + 8*(n/100))
+			case n == 39:
+				s.fg = fg
+			case n == 49:
+				s.bg = bg
+			case n == 38, n == 48:
+				c, used, ok := extendedColor(params[i+1:])
+				i += used
+				if ok && n == 38 {
+					s.fg = c
+				} else if ok {
+					s.bg = c
+				}
+			}
+		}
+	}
+	return s
+}
+
+// The color of the parameters after 38 or 48: 2;r;g;b or 5;n. Also returns
+// how many parameters it takes.
+func extendedColor(params []string) (c colorful.Color, used int, ok bool) {
+	num := func(i int) int {
+		n := 0
+		if i < len(params) {
+			n, _ = strconv.Atoi(params[i])
+		}
+		return n
+	}
+	switch num(0) {
+	case 2:
+		return colorful.Color{R: float64(num(1)) / 255, G: float64(num(2)) / 255, B: float64(num(3)) / 255}, 4, true
+	case 5:
+		return ansiColor(num(1)), 2, true
+	}
+	return c, len(params), false
+}
+
+// RGB of a color of the 256-color palette
+func ansiColor(n int) colorful.Color {
+	return termenv.ConvertToRGB(termenv.ANSI256Color(n))
+}
+
+// A cell of the character grid: a character and its combining marks, or
+// nothing for a blank or the second column of a wide character
+type shotCell struct {
+	text      []rune
+	fg, bg    colorful.Color
+	face      int // index in screenshotFonts
+	underline bool
+}
+
+// The cell of a character in a style, resolving reverse video and faint
+// text, which is blended halfway into the background
+func (s sgrStyle) cell(r rune) shotCell {
+	c := shotCell{text: []rune{r}, fg: s.fg, bg: s.bg, underline: s.underline}
+	if s.reverse {
+		c.fg, c.bg = c.bg, c.fg
+	}
+	if s.faint {
+		c.fg = c.fg.BlendRgb(c.bg, 0.5)
+	}
+	if s.bold {
+		c.face++
+	}
+	if s.italic {
+		c.face += 2
+	}
+	return c
+}
+
+// Lays out a frame on the character grid of a width x height terminal, in
+// the default colors where it has no style
+func screenshotGrid(frame string, width, height int, fg, bg colorful.Color) [][]shotCell {
+	lines := strings.Split(frame, "\n")
+	grid := make([][]shotCell, height)
+	for y := range grid {
+		row := make([]shotCell, width)
+		for x := range row {
+			row[x] = shotCell{fg: fg, bg: bg}
+		}
+		if y < len(lines) {
+			x, last := 0, -1
+			for _, c := range parseCells(lines[y]) {
+				w := ansi.StringWidth(string(c.r))
+				if w == 0 {
+					if last >= 0 {
+						row[last].text = append(row[last].text, c.r)
+					}
+					continue
+				}
+				if x+w > width {
+					break
+				}
+				row[x] = parseSGR(c.sgr, fg, bg).cell(c.r)
+				for i := 1; i < w; i++ {
+					row[x+i] = shotCell{fg: row[x].fg, bg: row[x].bg}
+				}
+				last = x
+				x += w
+			}
+		}
+		shapeArabic(row)
+		grid[y] = row
+	}
+	return grid
+}
+
+// Arabic letters that join, with the first of their presentation forms
+// (isolated, final, initial, medial) and how many they have: 2 for letters
+// that only join the letter before them
+var arabicForms = map[rune]struct {
+	isolated rune
+	forms    int
+}{
+	'آ': {0xFE81, 2}, 'أ': {0xFE83, 2}, 'ؤ': {0xFE85, 2}, 'إ': {0xFE87, 2}, 'ئ': {0xFE89, 4},
+	'ا': {0xFE8D, 2}, 'ب': {0xFE8F, 4}, 'ة': {0xFE93, 2}, 'ت': {0xFE95, 4}, 'ث': {0xFE99, 4},
+	'ج': {0xFE9D, 4}, 'ح': {0xFEA1, 4}, 'خ': {0xFEA5, 4}, 'د': {0xFEA9, 2}, 'ذ': {0xFEAB, 2},
+	'ر': {0xFEAD, 2}, 'ز': {0xFEAF, 2}, 'س': {0xFEB1, 4}, 'ش': {0xFEB5, 4}, 'ص': {0xFEB9, 4},
+	'ض': {0xFEBD, 4}, 'ط': {0xFEC1, 4}, 'ظ': {0xFEC5, 4}, 'ع': {0xFEC9, 4}, 'غ': {0xFECD, 4},
+	'ف': {0xFED1, 4}, 'ق': {0xFED5, 4}, 'ك': {0xFED9, 4}, 'ل': {0xFEDD, 4}, 'م': {0xFEE1, 4},
+	'ن': {0xFEE5, 4}, 'ه': {0xFEE9, 4}, 'و': {0xFEED, 2}, 'ى': {0xFEEF, 2}, 'ي': {0xFEF1, 4},
+	'ـ': {'ـ', 4}, // tatweel, which only stretches the joins
+}
+
+// Joins the Arabic letters of a grid row, which holds them in visual order
+// as terminals do: a letter's logical predecessor is on its right
+func shapeArabic(row []shotCell) {
+	base := make([]rune, len(row))
+	for i, c := range row {
+		if len(c.text) > 0 {
+			base[i] = c.text[0]
+		}
+	}
+	forms := func(i int) int {
+		if i < 0 || i >= len(base) {
+			return 0
+		}
+		return arabicForms[base[i]].forms
+	}
+
+	for i, r := range base {
+		f, ok := arabicForms[r]
+		if !ok || r == 'ـ' {
+			continue
+		}
+		prev := forms(i+1) == 4
+		next := f.forms == 4 && forms(i-1) >= 2
+		switch {
+		case prev && next:
+			r = f.isolated + 3
+		case prev:
+			r = f.isolated + 1
+		case next:
+			r = f.isolated + 2
+		default:
+			r = f.isolated
+		}
+		row[i].text[0] = r
+	}
+}
+
+// A glyph of one of the screenshot fonts
+type glyphKey struct {
+	face  int
+	glyph sfnt.GlyphIndex
+}
+
+// A frame laid out for drawing at a font size, with the cell size and
+// baseline of the font and the outlines of the glyphs drawn so far
+type screenshot struct {
+	grid               [][]shotCell
+	bg                 colorful.Color
+	size               float64
+	cellW, cellH, base float64
+	outlines           map[glyphKey]sfnt.Segments
+	buf                sfnt.Buffer
+}
+
+// The tab, language, theme and size of the export options as a screenshot
+// whose font is size pixels
+func newScreenshot(o exportOptions, size float64) *screenshot {
+	colors := themes[o.theme].Colors
+	s := &screenshot{bg: paletteColor(colors.Highlight), size: size, outlines: map[glyphKey]sfnt.Segments{}}
+	s.grid = screenshotGrid(o.frame(), o.width, o.height, paletteColor(colors.Text), s.bg)
+
+	f := screenshotFonts()[0]
+	ppem := fixed.Int26_6(size * 64)
+	metrics, _ := f.Metrics(&s.buf, ppem, font.HintingNone)
+	m, _ := f.GlyphIndex(&s.buf, 'M')
+	advance, _ := f.GlyphAdvance(&s.buf, m, ppem, font.HintingNone)
+	s.cellW = float64(advance) / 64
+	s.cellH = float64(metrics.Ascent+metrics.Descent) / 64
+	s.base = float64(metrics.Ascent) / 64
+	return s
+}
+
+// Margin around the grid, the height of a line
+func (s *screenshot) padding() float64 {
+	return s.cellH
+}
+
+func (s *screenshot) bounds() (width, height float64) {
+	return float64(len(s.grid[0]))*s.cellW + 2*s.padding(), float64(len(s.grid))*s.cellH + 2*s.padding()
+}
+
+// The glyph of a character in a face, in the regular face when that one
+// doesn't have it
+func (s *screenshot) glyph(face int, r rune) glyphKey {
+	fonts := screenshotFonts()
+	if g, err := fonts[face].GlyphIndex(&s.buf, r); err == nil && g != 0 {
+		return glyphKey{face, g}
+	}
+	g, _ := fonts[0].GlyphIndex(&s.buf, r)
+	return glyphKey{0, g}
+}
+
+// Outline of a glyph at the screenshot's size, from its origin on the
+// baseline with y growing downwards
+func (s *screenshot) outline(k glyphKey) sfnt.Segments {
+	if segments, ok := s.outlines[k]; ok {
+		return segments
+	}
+	segments, _ := screenshotFonts()[k.face].LoadGlyph(&s.buf, k.glyph, fixed.Int26_6(s.size*64), nil)
+	s.outlines[k] = append(sfnt.Segments(nil), segments...)
+	return s.outlines[k]
+}
+
+// Draws the screenshot with the drawing functions of a format: rect for the
+// background, cell backgrounds and underlines, then glyph for the
+// characters, at their origin on the baseline
+func (s *screenshot) draw(rect func(x, y, w, h float64, c colorful.Color), glyph func(k glyphKey, x, y float64, c colorful.Color)) {
+	width, height := s.bounds()
+	rect(0, 0, width, height, s.bg)
+	pad := s.padding()
+
+	for y, row := range s.grid {
+		top := pad + float64(y)*s.cellH
+		// Backgrounds in runs of one color
+		for x := 0; x < len(row); {
+			end := x + 1
+			for end < len(row) && row[end].bg == row[x].bg {
+				end++
+			}
+			if row[x].bg != s.bg {
+				rect(pad+float64(x)*s.cellW, top, float64(end-x)*s.cellW, s.cellH, row[x].bg)
+			}
+			x = end
+		}
+
+		for x, c := range row {
+			left := pad + float64(x)*s.cellW
+			if c.underline {
+				rect(left, top+s.base+s.size/10, s.cellW, max(1, s.size/16), c.fg)
+			}
+			for i, r := range c.text {
+				if r == ' ' {
+					continue
+				}
+				k := s.glyph(c.face, r)
+				origin := left
+				// Marks the font draws left of their origin go on the character
+				// before them
+				if i > 0 && s.outline(k).Bounds().Max.X <= 0 {
+					origin += s.cellW
+				}
+				glyph(k, origin, top+s.base, c.fg)
+			}
+		}
+	}
+}
+
+// Rounds a coordinate for SVG output
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// An outline as SVG path data
+func svgPath(segments sfnt.Segments) string {
+	var b strings.Builder
+	point := func(p fixed.Point26_6) {
+		b.WriteString(" " + svgNumber(float64(p.X)/64) + " " + svgNumber(float64(p.Y)/64))
+	}
+	for i, seg := range segments {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			if i > 0 {
+				b.WriteString("Z")
+			}
+			b.WriteString("M")
+			point(seg.Args[0])
+		case sfnt.SegmentOpLineTo:
+			b.WriteString("L")
+			point(seg.Args[0])
+		case sfnt.SegmentOpQuadTo:
+			b.WriteString("Q")
+			point(seg.Args[0])
+			point(seg.Args[1])
+		case sfnt.SegmentOpCubeTo:
+			b.WriteString("C")
+			point(seg.Args[0])
+			point(seg.Args[1])
+			point(seg.Args[2])
+		}
+	}
+	if len(segments) > 0 {
+		b.WriteString("Z")
+	}
+	return b.String()
+}
+
+// Writes a screenshot of a tab as SVG (export --format svg). Characters are
+// drawn with the font's outlines, each glyph defined once, so it looks the
+// same in any viewer.
+func writeSVG(w io.Writer, o exportOptions) error {
+	s := newScreenshot(o, screenshotFontSize)
+	var defs, body strings.Builder
+	ids := map[glyphKey]int{}
+	s.draw(func(x, y, w, h float64, c colorful.Color) {
+		fmt.Fprintf(&body, "<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n", svgNumber(x), svgNumber(y), svgNumber(w), svgNumber(h), c.Hex())
+	}, func(k glyphKey, x, y float64, c colorful.Color) {
+		id, ok := ids[k]
+		if !ok {
+			id = len(ids)
+			ids[k] = id
+			fmt.Fprintf(&defs, "<path id=\"g%d\" d=\"%s\"/>\n", id, svgPath(s.outline(k)))
+		}
+		fmt.Fprintf(&body, "<use href=\"#g%d\" x=\"%s\" y=\"%s\" fill=\"%s\"/>\n", id, svgNumber(x), svgNumber(y), c.Hex())
+	})
+
+	width, height := s.bounds()
+	_, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %[1]s %[2]s\" shape-rendering=\"crispEdges\">\n<defs>\n%s</defs>\n<g shape-rendering=\"auto\">\n%s</g>\n</svg>\n",
+		svgNumber(width), svgNumber(height), defs.String(), body.String())
+	return err
+}
+
+// Coverage of a glyph, positioned relative to its origin
+func glyphMask(segments sfnt.Segments) *image.Alpha {
+	b := segments.Bounds()
+	r := image.Rect(b.Min.X.Floor(), b.Min.Y.Floor(), b.Max.X.Ceil(), b.Max.Y.Ceil())
+	mask := image.NewAlpha(r)
+	if r.Empty() {
+		return mask
+	}
+	z := vector.NewRasterizer(r.Dx(), r.Dy())
+	at := func(p fixed.Point26_6) (float32, float32) {
+		return float32(p.X)/64 - float32(r.Min.X), float32(p.Y)/64 - float32(r.Min.Y)
+	}
+	for _, seg := range segments {
+		ax, ay := at(seg.Args[0])
+		bx, by := at(seg.Args[1])
+		cx, cy := at(seg.Args[2])
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			z.ClosePath()
+			z.MoveTo(ax, ay)
+		case sfnt.SegmentOpLineTo:
+			z.LineTo(ax, ay)
+		case sfnt.SegmentOpQuadTo:
+			z.QuadTo(ax, ay, bx, by)
+		case sfnt.SegmentOpCubeTo:
+			z.CubeTo(ax, ay, bx, by, cx, cy)
+		}
+	}
+	z.ClosePath()
+	z.Draw(mask, r, image.Opaque, image.Point{})
+	return mask
+}
+
+// Writes a screenshot of a tab as PNG (export --format png), rasterized
+// from the same outlines as the SVG
+func writePNG(w io.Writer, o exportOptions) error {
+	s := newScreenshot(o, screenshotFontSize*screenshotScale)
+	width, height := s.bounds()
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(width)), int(math.Ceil(height))))
+	masks := map[glyphKey]*image.Alpha{}
+	s.draw(func(x, y, w, h float64, c colorful.Color) {
+		r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+		draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+	}, func(k glyphKey, x, y float64, c colorful.Color) {
+		mask, ok := masks[k]
+		if !ok {
+			mask = glyphMask(s.outline(k))
+			masks[k] = mask
+		}
+		r := mask.Rect.Add(image.Pt(int(math.Round(x)), int(math.Round(y))))
+		draw.DrawMask(img, r, image.NewUniform(c), image.Point{}, mask, mask.Rect.Min, draw.Over)
+	})
+	return png.Encode(w, img)
+}
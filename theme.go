@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// A color scheme, border set and title emphasis for the whole UI. Besides
+// the built-in themes, each *.toml file in the themes directory adds one;
+// fields it leaves out keep the default theme's values.
+type theme struct {
+	Name     string   `toml:"name"`
+	Border   string   `toml:"border"`   // rounded, heavy, double or ascii
+	Emphasis []string `toml:"emphasis"` // title attributes: bold, italic, underline, faint
+	Colors   palette  `toml:"colors"`
+}
+
+// Theme colors, as "#rrggbb" or ANSI color numbers
+type palette struct {
+	Primary   string `toml:"primary"`   // accent: titles, frame, active tab
+	Secondary string `toml:"secondary"` // second accent
+	Text      string `toml:"text"`
+	Muted     string `toml:"muted"`     // hints, dates, inactive items
+	Warning   string `toml:"warning"`   // problems flagged by the review
+	Navbar    string `toml:"navbar"`    // navbar background
+	Highlight string `toml:"highlight"` // text drawn on the primary color
+}
+
+// Built-in themes; the first one is the default
+var builtinThemes = []theme{
+	{
+		Name:     "Blue",
+		Border:   "rounded",
+		Emphasis: []string{"bold"},
+		Colors: palette{
+			Primary:   "#0174DF", // Blue accent
+			Secondary: "#08298A", // Dark blue
+			Text:      "#FFFFFF",
+			Muted:     "#666666",
+			Warning:   "#DF7401", // Orange, flags problems
+			Navbar:    "#1a1a1a",
+			Highlight: "#000000",
+		},
+	},
+	{
+		Name:     "Light",
+		Border:   "rounded",
+		Emphasis: []string{"bold"},
+		Colors: palette{
+			Primary:   "#0B5CAD",
+			Secondary: "#7FB2E5",
+			Text:      "#1F2328",
+			Muted:     "#6E7781",
+			Warning:   "#BC4C00",
+			Navbar:    "#E6E6E6",
+			Highlight: "#FFFFFF",
+		},
+	},
+	{
+		Name:     "High contrast",
+		Border:   "heavy",
+		Emphasis: []string{"bold", "underline"},
+		Colors: palette{
+			Primary:   "#FFFF00",
+			Secondary: "#00FFFF",
+			Text:      "#FFFFFF",
+			Muted:     "#C0C0C0",
+			Warning:   "#FF5F5F",
+			Navbar:    "#000000",
+			Highlight: "#000000",
+		},
+	},
+	{
+		Name:     "Dracula",
+		Border:   "rounded",
+		Emphasis: []string{"bold"},
+		Colors: palette{
+			Primary:   "#BD93F9",
+			Secondary: "#FF79C6",
+			Text:      "#F8F8F2",
+			Muted:     "#6272A4",
+			Warning:   "#FFB86C",
+			Navbar:    "#282A36",
+			Highlight: "#282A36",
+		},
+	},
+	{
+		Name:     "Solarized",
+		Border:   "double",
+		Emphasis: []string{"bold"},
+		Colors: palette{
+			Primary:   "#268BD2",
+			Secondary: "#2AA198",
+			Text:      "#93A1A1",
+			Muted:     "#586E75",
+			Warning:   "#CB4B16",
+			Navbar:    "#073642",
+			Highlight: "#002B36",
+		},
+	},
+}
+
+// Themes available to sessions: the built-ins, then those loaded at startup
+var themes = slices.Clone(builtinThemes)
+
+// Frame glyphs of a border style, plus the rule drawn around entry titles
+type borderSet struct {
+	lipgloss.Border
+	rule string
+}
+
+var borderSets = map[string]borderSet{
+	"rounded": {lipgloss.RoundedBorder(), "━"},
+	"heavy":   {lipgloss.ThickBorder(), "━"},
+	"double":  {lipgloss.DoubleBorder(), "═"},
+	"ascii":   {lipgloss.ASCIIBorder(), "="},
+}
+
+// Styles of a theme, as used by the views
+type styles struct {
+	box       borderSet
+	title     lipgloss.Style
+	content   lipgloss.Style
+	muted     lipgloss.Style
+	frame     lipgloss.Style // border lines
+	warning   lipgloss.Style
+	highlight lipgloss.Style // text on the primary color: badges, cursor
+	navbar    lipgloss.Style
+}
+
+// Builds a theme's styles on the renderer of the output they are written
+// to, so colors are downsampled to what that terminal supports. With
+// NO_COLOR set, styles keep their attributes but drop every color.
+func newStyles(t theme, r *lipgloss.Renderer) styles {
+	noColor := r.Output().EnvNoColor()
+	fg := func(color string) lipgloss.Style {
+		if noColor {
+			return r.NewStyle()
+		}
+		return r.NewStyle().Foreground(lipgloss.Color(color))
+	}
+
+	c := t.Colors
+	title := fg(c.Primary)
+	for _, e := range t.Emphasis {
+		switch e {
+		case "bold":
+			title = title.Bold(true)
+		case "italic":
+			title = title.Italic(true)
+		case "underline":
+			title = title.Underline(true)
+		case "faint":
+			title = title.Faint(true)
+		}
+	}
+
+	s := styles{
+		box:       borderSets[t.Border],
+		title:     title,
+		content:   fg(c.Text),
+		muted:     fg(c.Muted),
+		frame:     fg(c.Primary),
+		warning:   fg(c.Warning),
+		highlight: fg(c.Highlight).Background(lipgloss.Color(c.Primary)).Bold(true),
+		navbar:    r.NewStyle().Background(lipgloss.Color(c.Navbar)),
+	}
+	if noColor {
+		// Attributes stand in for the colors that set things apart
+		s.muted = s.muted.Faint(true)
+		s.highlight = r.NewStyle().Reverse(true).Bold(true)
+		s.navbar = r.NewStyle()
+	}
+	return s
+}
+
+func (m *model) setTheme(i int) {
+	m.theme = i
+	m.styles = newStyles(themes[i], m.renderer)
+}
+
+// Binds the model to the renderer of its output (an SSH session's PTY or
+// stdout), which decides the color profile and background
+func (m *model) setRenderer(r *lipgloss.Renderer) {
+	// NO_COLOR makes the renderer drop all styling; it only asks for no
+	// colors, so terminals that have attributes keep them (see newStyles)
+	if r.Output().EnvNoColor() && r.Output().ColorProfile() != termenv.Ascii {
+		r.SetColorProfile(termenv.ANSI)
+	}
+	m.renderer = r
+	m.setTheme(m.theme)
+}
+
+// Switches to the next (step 1) or previous (step -1) theme
+func (m *model) cycleTheme(step int) {
+	n := len(themes)
+	m.setTheme(((m.theme+step)%n + n) % n)
+}
+
+// Position of a theme by name, ignoring case and treating spaces and
+// dashes alike ("high-contrast"), or -1
+func themeIndex(name string) int {
+	normalize := func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(s), " ", "-")
+	}
+	for i, t := range themes {
+		if normalize(t.Name) == normalize(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Default directory of user themes, e.g. ~/.config/termfolio/themes
+func themeDir() string {
+	if configDir() == "" {
+		return ""
+	}
+	return filepath.Join(configDir(), "themes")
+}
+
+// Adds the themes defined by the *.toml files of a directory, returning the
+// problems with files that could not be used. A missing directory is fine.
+func loadThemes(dir string) []error {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.toml"))
+
+	var errs []error
+	for _, file := range files {
+		t := builtinThemes[0]
+		t.Name = strings.TrimSuffix(filepath.Base(file), ".toml")
+		if _, err := toml.DecodeFile(file, &t); err != nil {
+			errs = append(errs, fmt.Errorf("theme: %s: %v", file, err))
+			continue
+		}
+		if err := t.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("theme: %s: %v", file, err))
+			continue
+		}
+		if i := themeIndex(t.Name); i >= 0 {
+			themes[i] = t // a file may redefine a built-in
+		} else {
+			themes = append(themes, t)
+		}
+	}
+	return errs
+}
+
+func (t theme) validate() error {
+	if _, ok := borderSets[t.Border]; !ok {
+		return fmt.Errorf("unknown border %q (available: rounded, heavy, double, ascii)", t.Border)
+	}
+	for _, e := range t.Emphasis {
+		if !slices.Contains([]string{"bold", "italic", "underline", "faint"}, e) {
+			return fmt.Errorf("unknown emphasis %q (available: bold, italic, underline, faint)", e)
+		}
+	}
+	return nil
+}
+
+// Names of the available themes, for flag help and errors
+func themeNames() []string {
+	names := make([]string, len(themes))
+	for i, t := range themes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Resolves the --theme flag; -1 when it is empty, leaving the choice to
+// the terminal background (see initialTheme)
+func startTheme(name string) (int, error) {
+	if name == "" {
+		return -1, nil
+	}
+	if i := themeIndex(name); i >= 0 {
+		return i, nil
+	}
+	return 0, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(themeNames(), ", "))
+}
+
+// The theme a model starts with: the one given with --theme, otherwise
+// Light on a light terminal background and the default on a dark one
+func initialTheme(chosen int, r *lipgloss.Renderer) int {
+	if chosen >= 0 {
+		return chosen
+	}
+	if !r.HasDarkBackground() {
+		if i := themeIndex("Light"); i >= 0 {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"termfolio/data"
+)
+
+// The contact as a vCard 4.0 (RFC 6350), with the job title in the
+// reader's language
+func vCard(c data.Contact, title string) string {
+	given, family := c.Name, ""
+	if i := strings.LastIndex(c.Name, " "); i >= 0 {
+		given, family = c.Name[:i], c.Name[i+1:]
+	}
+
+	lines := []string{
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:" + vCardEscape(c.Name),
+		"N:" + vCardEscape(family) + ";" + vCardEscape(given) + ";;;",
+	}
+	add := func(property, value string) {
+		if value != "" {
+			lines = append(lines, property+":"+value)
+		}
+	}
+	add("TITLE", vCardEscape(title))
+	add("EMAIL;TYPE=work", vCardEscape(c.Email))
+	if c.Phone != "" {
+		// Telephone numbers are tel: URIs, without spaces
+		add("TEL;VALUE=uri;TYPE=cell", "tel:"+strings.ReplaceAll(c.Phone, " ", ""))
+	}
+	if c.Location != "" {
+		// ADR parts: PO box, extended, street, city, region, code, country
+		city, region, country := splitLocation(c.Location)
+		add("ADR", ";;;"+vCardEscape(city)+";"+vCardEscape(region)+";;"+vCardEscape(country))
+	}
+	if c.LinkedIn != "" {
+		add("URL", "https://linkedin.com/in/"+c.LinkedIn)
+	}
+	if c.GitHub != "" {
+		add("URL", "https://github.com/"+c.GitHub)
+	}
+	lines = append(lines, "END:VCARD")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(vCardFold(line))
+	}
+	return b.String()
+}
+
+// Splits a location written "City, Country" or "City, Region, Country", as
+// LinkedIn writes them; a location without commas is taken as the country
+func splitLocation(location string) (city, region, country string) {
+	parts := strings.Split(location, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	country = parts[len(parts)-1]
+	if len(parts) > 1 {
+		city = parts[0]
+		region = strings.Join(parts[1:len(parts)-1], ", ")
+	}
+	return city, region, country
+}
+
+// Escapes the characters with a meaning in vCard values
+func vCardEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`).Replace(s)
+}
+
+// Ends a content line with CRLF, folding it into lines of at most 75
+// octets continued by a space, without splitting a UTF-8 sequence
+func vCardFold(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // the continuation's leading space counts
+	}
+	b.WriteString(line + "\r\n")
+	return b.String()
+}
+
+// Writes the owner's vCard (export --format vcf)
+func writeVCard(w io.Writer, o exportOptions) error {
+	_, err := io.WriteString(w, vCard(owner, o.model().t("subtitle")))
+	return err
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"termfolio/data"
+)
+
+func TestSplitLocation(t *testing.T) {
+	tests := []struct {
+		in                    string
+		city, region, country string
+	}{
+		{"France", "", "", "France"},
+		{"Paris, France", "Paris", "", "France"},
+		{"Paris, Île-de-France, France", "Paris", "Île-de-France", "France"},
+		{"Meknes ,Maroc", "Meknes", "", "Maroc"},
+	}
+	for _, tt := range tests {
+		city, region, country := splitLocation(tt.in)
+		if city != tt.city || region != tt.region || country != tt.country {
+			t.Errorf("splitLocation(%q) = %q, %q, %q, want %q, %q, %q", tt.in, city, region, country, tt.city, tt.region, tt.country)
+		}
+	}
+}
+
+func TestVCardAddress(t *testing.T) {
+	card := vCard(data.Contact{Name: "Ada Lovelace", Location: "London, England, United Kingdom"}, "")
+	if want := "ADR:;;;London;England;;United Kingdom\r\n"; !strings.Contains(card, want) {
+		t.Errorf("vCard() =\n%s\nwant the line %q", card, want)
+	}
+}